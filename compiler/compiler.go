@@ -0,0 +1,137 @@
+// Package compiler provides the public interface for compiling schema
+// documents into validated document models and emitting output
+// generated from them
+package compiler
+
+import (
+	"fmt"
+	"io/fs"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// Options represents the options of a compiler
+type Options struct {
+	// BuildTime is stamped into the document metadata,
+	// a zero build time omits it for reproducible output
+	BuildTime time.Time
+
+	// Order defines the order types, fields, relations
+	// and enumeration values are listed in
+	Order rend.Order
+}
+
+// Result represents the result of a compilation
+type Result struct {
+	// File is the name of the compiled file
+	File string
+
+	// Model is the validated document model,
+	// it's nil if the diagnostics contain errors
+	Model *rend.Document
+
+	// Source maps the declarations to their positions in the file
+	Source *document.SourceMap
+
+	Diagnostics Diagnostics
+}
+
+// Compiler compiles schema documents and emits output
+// generated from the compiled document models
+type Compiler struct {
+	options  Options
+	emitters map[string]Emitter
+}
+
+// New creates a new compiler with the built-in emitters registered
+func New(options Options) *Compiler {
+	c := &Compiler{
+		options:  options,
+		emitters: make(map[string]Emitter),
+	}
+	for _, emitter := range builtinEmitters() {
+		c.emitters[emitter.Name()] = emitter
+	}
+	return c
+}
+
+// Compile compiles the given schema document read from the file
+// of the given name. Returns an error if the document can't be parsed,
+// problems of the schema are reported as diagnostics
+func (c *Compiler) Compile(file string, buf []byte) (*Result, error) {
+	doc, _, err := document.New(buf)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read %s: %s", file, err)
+	}
+
+	model, errs, _, err := rend.NewModel(doc, c.options.BuildTime)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't initialize document model: %s", err)
+	}
+	model.Order = c.options.Order
+
+	result := &Result{
+		File:        file,
+		Model:       model,
+		Source:      doc.Source,
+		Diagnostics: NewDiagnostics(file, doc.Source, errs),
+	}
+	if result.Diagnostics.HasErrors() {
+		result.Model = nil
+	}
+	return result, nil
+}
+
+// CompileFile compiles the schema document located at the given path
+func (c *Compiler) CompileFile(path string) (*Result, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read file: %s", err)
+	}
+	return c.Compile(path, buf)
+}
+
+// CompileFS compiles the schema document of the given name
+// in the given file system
+func (c *Compiler) CompileFS(fsys fs.FS, name string) (*Result, error) {
+	buf, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read file: %s", err)
+	}
+	return c.Compile(name, buf)
+}
+
+// Register registers the given emitter.
+// Returns an error if an emitter of the same name is already registered
+func (c *Compiler) Register(emitter Emitter) error {
+	if emitter == nil {
+		return fmt.Errorf("missing emitter")
+	}
+	name := emitter.Name()
+	if _, isRegistered := c.emitters[name]; isRegistered {
+		return fmt.Errorf("emitter '%s' is already registered", name)
+	}
+	c.emitters[name] = emitter
+	return nil
+}
+
+// Emitter returns the emitter registered by the given name
+// and true if it's registered, otherwise returns false
+func (c *Compiler) Emitter(name string) (Emitter, bool) {
+	emitter, isRegistered := c.emitters[name]
+	return emitter, isRegistered
+}
+
+// Emitters returns the names of the registered emitters sorted by name
+func (c *Compiler) Emitters() []string {
+	names := make([]string, 0, len(c.emitters))
+	for name := range c.emitters {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,153 @@
+package compiler
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/romshark/TypeBook/rend"
+)
+
+// compile compiles a test schema with the given declarations
+func compile(t *testing.T, declarations string) *Result {
+	t.Helper()
+	result, err := New(Options{}).Compile(
+		"schema.yml",
+		[]byte("title: Test\n"+declarations),
+	)
+	if err != nil {
+		t.Fatalf("couldn't compile: %s", err)
+	}
+	return result
+}
+
+// TestCompileFS verifies that documents are compiled from file systems
+// and emitted by custom emitters
+func TestCompileFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"schema.yml": {Data: []byte("title: Test\n" +
+			"scalar types:\n" +
+			"  String:\n" +
+			"    kind: string\n" +
+			"entity types:\n" +
+			"  User:\n" +
+			"    meta:\n" +
+			"      name:\n" +
+			"        type: String\n")},
+	}
+
+	c := New(Options{})
+	if err := c.Register(NewEmitter(
+		"names",
+		func(model *rend.Document, w io.Writer) error {
+			for _, t := range model.OrderedEntityTypes() {
+				if _, err := io.WriteString(w, t.TypeName); err != nil {
+					return err
+				}
+			}
+			return nil
+		},
+	)); err != nil {
+		t.Fatalf("couldn't register emitter: %s", err)
+	}
+
+	result, err := c.CompileFS(fsys, "schema.yml")
+	if err != nil {
+		t.Fatalf("couldn't compile: %s", err)
+	}
+	if result.Model == nil || len(result.Diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %v", result.Diagnostics)
+	}
+	var buf bytes.Buffer
+	if err := c.Emit("names", result, &buf); err != nil {
+		t.Fatalf("couldn't emit: %s", err)
+	}
+	if buf.String() != "User" {
+		t.Fatalf("unexpected output: %q", buf.String())
+	}
+}
+
+// TestCompileInvalid verifies that schema errors are reported
+// as diagnostics without a model
+func TestCompileInvalid(t *testing.T) {
+	result := compile(t, "composite types:\n"+
+		"  Point:\n"+
+		"    meta:\n"+
+		"      x:\n"+
+		"        type: Undefined\n")
+	if result.Model != nil {
+		t.Fatalf("expected no model for an invalid document")
+	}
+	if !result.Diagnostics.HasErrors() {
+		t.Fatalf("expected errors")
+	}
+	if result.Diagnostics[0].Location.Line != 5 {
+		t.Fatalf(
+			"expected the error on line 5, got %d",
+			result.Diagnostics[0].Location.Line,
+		)
+	}
+	if err := New(Options{}).Register(NewEmitter("html", nil)); err == nil {
+		t.Fatalf("expected registering a duplicate emitter to fail")
+	}
+}
+
+// TestEmitSite verifies that the site emitter writes a page per type
+// linking other pages relatively
+func TestEmitSite(t *testing.T) {
+	c := New(Options{})
+	result := compile(t, "scalar types:\n"+
+		"  billing.Amount:\n"+
+		"    kind: number\n"+
+		"entity types:\n"+
+		"  billing.Invoice:\n"+
+		"    meta:\n"+
+		"      total:\n"+
+		"        type: Amount\n")
+	var buf bytes.Buffer
+	if err := c.Emit("site", result, &buf); err != nil {
+		t.Fatalf("couldn't emit: %s", err)
+	}
+	archive, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("couldn't read archive: %s", err)
+	}
+
+	pages := make(map[string]string, len(archive.File))
+	for _, file := range archive.File {
+		reader, err := file.Open()
+		if err != nil {
+			t.Fatalf("couldn't open %s: %s", file.Name, err)
+		}
+		content, err := io.ReadAll(reader)
+		reader.Close()
+		if err != nil {
+			t.Fatalf("couldn't read %s: %s", file.Name, err)
+		}
+		pages[file.Name] = string(content)
+	}
+	for _, path := range []string{
+		"index.html",
+		"style.css",
+		"scalar-types/index.html",
+		"scalar-types/billing.Amount.html",
+		"entity-types/billing.Invoice.html",
+		"relations/index.html",
+	} {
+		if _, isWritten := pages[path]; !isWritten {
+			t.Fatalf("missing page %s", path)
+		}
+	}
+	invoice := pages["entity-types/billing.Invoice.html"]
+	for _, link := range []string{
+		`href="../style.css"`,
+		`href="../scalar-types/billing.Amount.html"`,
+	} {
+		if !strings.Contains(invoice, link) {
+			t.Fatalf("missing link %s", link)
+		}
+	}
+}
@@ -0,0 +1,285 @@
+package compiler
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// diagnosticsFormats lists the supported diagnostics output formats
+var diagnosticsFormats = map[string]func(io.Writer, Diagnostics) error{
+	"text":  writeTextDiagnostics,
+	"json":  writeJSONDiagnostics,
+	"sarif": writeSARIFDiagnostics,
+}
+
+// diagnosticsVersion is the version of the JSON diagnostics format.
+// It must be incremented whenever the format changes incompatibly
+const diagnosticsVersion = 1
+
+// Location represents the location of a diagnostic
+type Location struct {
+	// File is the path of the file the diagnostic refers to
+	File string `json:"file"`
+
+	// Path is the path of mapping keys and list indexes
+	// leading to the location in the file
+	Path []string `json:"path"`
+
+	// Description describes the location in a human readable form
+	Description string `json:"description"`
+
+	// Line and Column are 1-based and omitted if unknown
+	Line   int `json:"line,omitempty"`
+	Column int `json:"column,omitempty"`
+}
+
+// Diagnostic represents a model error in a machine readable form
+type Diagnostic struct {
+	Code     rend.ErrorCode `json:"code"`
+	Severity string         `json:"severity"`
+	Message  string         `json:"message"`
+	Location Location       `json:"location"`
+	Fixes    []Fix          `json:"fixes,omitempty"`
+}
+
+// Fix represents a suggested edit replacing the original text
+// of the value at the location by the replacement
+type Fix struct {
+	Description string   `json:"description"`
+	Location    Location `json:"location"`
+	Original    string   `json:"original"`
+	Replacement string   `json:"replacement"`
+}
+
+// newLocation resolves the position of the given model error location
+// in the given file using the source map
+func newLocation(
+	file string,
+	source *document.SourceMap,
+	location rend.Location,
+) Location {
+	path := location.Path
+	if path == nil {
+		path = []string{}
+	}
+	position, _ := source.LookupOccurrence(path, location.Occurrence)
+	return Location{
+		File:        file,
+		Path:        path,
+		Description: location.Description,
+		Line:        position.Line,
+		Column:      position.Column,
+	}
+}
+
+// Diagnostics represents a list of diagnostics
+type Diagnostics []Diagnostic
+
+// NewDiagnostics converts the given model errors reported for the given
+// file to diagnostics resolving their positions using the source map.
+// Diagnostics are ordered by their positions, diagnostics of unknown
+// positions come first
+func NewDiagnostics(
+	file string,
+	source *document.SourceMap,
+	errs rend.ModelErrors,
+) Diagnostics {
+	diagnostics := make(Diagnostics, len(errs))
+	for i, err := range errs {
+		diagnostics[i] = Diagnostic{
+			Code:     err.Code,
+			Severity: err.Severity.String(),
+			Message:  err.Message,
+			Location: newLocation(file, source, err.Location),
+		}
+		for _, fixIt := range err.FixIts {
+			diagnostics[i].Fixes = append(diagnostics[i].Fixes, Fix{
+				Description: fixIt.Description,
+				Location:    newLocation(file, source, fixIt.Location),
+				Original:    fixIt.Original,
+				Replacement: fixIt.Replacement,
+			})
+		}
+	}
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		a, b := diagnostics[i].Location, diagnostics[j].Location
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return diagnostics
+}
+
+// HasErrors returns true if any of the diagnostics is an error,
+// otherwise returns false
+func (diagnostics Diagnostics) HasErrors() bool {
+	for _, diag := range diagnostics {
+		if diag.Severity == rend.SeverityError.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// IsDiagnosticsFormat returns true if the given diagnostics output
+// format is supported, otherwise returns false
+func IsDiagnosticsFormat(format string) bool {
+	_, isSupported := diagnosticsFormats[format]
+	return isSupported
+}
+
+// Write writes the diagnostics in the given format
+// which is either "text", "json" or "sarif"
+func (diagnostics Diagnostics) Write(w io.Writer, format string) error {
+	write, isSupported := diagnosticsFormats[format]
+	if !isSupported {
+		return fmt.Errorf("unsupported diagnostics format: '%s'", format)
+	}
+	return write(w, diagnostics)
+}
+
+// writeTextDiagnostics writes the given diagnostics in a human readable
+// form grouping them by severity
+func writeTextDiagnostics(w io.Writer, diagnostics Diagnostics) error {
+	for _, severity := range []rend.Severity{
+		rend.SeverityError,
+		rend.SeverityWarning,
+		rend.SeverityInfo,
+	} {
+		filtered := make(Diagnostics, 0, len(diagnostics))
+		for _, diag := range diagnostics {
+			if diag.Severity == severity.String() {
+				filtered = append(filtered, diag)
+			}
+		}
+		if len(filtered) < 1 {
+			continue
+		}
+		if _, err := fmt.Fprintf(
+			w,
+			"%d %ss:\n",
+			len(filtered),
+			severity,
+		); err != nil {
+			return err
+		}
+		for i, diag := range filtered {
+			if _, err := fmt.Fprintf(
+				w,
+				" %d (%s): %s in %s\n",
+				i,
+				diag.Code,
+				diag.Message,
+				diag.Location.Description,
+			); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// writeJSONDiagnostics writes the given diagnostics as a JSON object
+func writeJSONDiagnostics(w io.Writer, diagnostics Diagnostics) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Version     int         `json:"version"`
+		Diagnostics Diagnostics `json:"diagnostics"`
+	}{
+		Version:     diagnosticsVersion,
+		Diagnostics: diagnostics,
+	})
+}
+
+// sarifLevels maps severities to SARIF result levels
+var sarifLevels = map[string]string{
+	rend.SeverityError.String():   "error",
+	rend.SeverityWarning.String(): "warning",
+	rend.SeverityInfo.String():    "note",
+}
+
+// writeSARIFDiagnostics writes the given diagnostics as a SARIF 2.1.0 log
+// https://docs.oasis-open.org/sarif/sarif/v2.1.0/sarif-v2.1.0.html
+func writeSARIFDiagnostics(w io.Writer, diagnostics Diagnostics) error {
+	type object = map[string]interface{}
+
+	// Declare a rule for every reported error code
+	codes := make([]string, 0)
+	ruleIndexes := make(map[rend.ErrorCode]int)
+	for _, diag := range diagnostics {
+		if _, isDeclared := ruleIndexes[diag.Code]; !isDeclared {
+			ruleIndexes[diag.Code] = 0
+			codes = append(codes, string(diag.Code))
+		}
+	}
+	sort.Strings(codes)
+	rules := make([]object, len(codes))
+	for i, code := range codes {
+		ruleIndexes[rend.ErrorCode(code)] = i
+		rules[i] = object{
+			"id":   code,
+			"name": code,
+		}
+	}
+
+	results := make([]object, len(diagnostics))
+	for i, diag := range diagnostics {
+		// Code scanning requires a region, fall back to the first line
+		// if the position is unknown
+		line, column := diag.Location.Line, diag.Location.Column
+		if line < 1 {
+			line, column = 1, 1
+		}
+		results[i] = object{
+			"ruleId":    diag.Code,
+			"ruleIndex": ruleIndexes[diag.Code],
+			"level":     sarifLevels[diag.Severity],
+			"message": object{
+				"text": fmt.Sprintf(
+					"%s in %s",
+					diag.Message,
+					diag.Location.Description,
+				),
+			},
+			"locations": []object{{
+				"physicalLocation": object{
+					"artifactLocation": object{
+						"uri": filepath.ToSlash(diag.Location.File),
+					},
+					"region": object{
+						"startLine":   line,
+						"startColumn": column,
+					},
+				},
+				"logicalLocations": []object{{
+					"fullyQualifiedName": rend.FormatPath(diag.Location.Path),
+				}},
+			}},
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(object{
+		"$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+		"version": "2.1.0",
+		"runs": []object{{
+			"tool": object{
+				"driver": object{
+					"name":           "TypeBook",
+					"informationUri": "https://github.com/romshark/TypeBook",
+					"rules":          rules,
+				},
+			},
+			"results": results,
+		}},
+	})
+}
@@ -0,0 +1,84 @@
+package compiler
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+)
+
+// update overwrites the golden files with the written diagnostics
+var update = flag.Bool("update", false, "update the golden files")
+
+// TestWriteDiagnostics verifies the diagnostics written
+// in the machine readable formats against the golden files in testdata
+func TestWriteDiagnostics(t *testing.T) {
+	for _, tt := range []struct {
+		name         string
+		declarations string
+	}{
+		{
+			name: "errors",
+			declarations: "scalar types:\n" +
+				"  String:\n" +
+				"    kind: string\n" +
+				"entity types:\n" +
+				"  User:\n" +
+				"    meta:\n" +
+				"      name:\n" +
+				"        type: Strin\n" +
+				"      email:\n" +
+				"        type: Email\n",
+		},
+		{
+			name: "warnings",
+			declarations: "scalar types:\n" +
+				"  String:\n" +
+				"    kind: string\n" +
+				"  Name:\n" +
+				"    kind: string\n" +
+				"    deprecated: true\n" +
+				"entity types:\n" +
+				"  User:\n" +
+				"    meta:\n" +
+				"      name:\n" +
+				"        type: Name\n",
+		},
+	} {
+		for _, format := range []string{"json", "sarif"} {
+			t.Run(tt.name+"."+format, func(t *testing.T) {
+				result := compile(t, tt.declarations)
+				if len(result.Diagnostics) < 1 {
+					t.Fatalf("expected diagnostics")
+				}
+				var out bytes.Buffer
+				if err := result.Diagnostics.Write(&out, format); err != nil {
+					t.Fatalf("couldn't write diagnostics: %s", err)
+				}
+
+				golden := filepath.Join("testdata", tt.name+"."+format)
+				if *update {
+					if err := ioutil.WriteFile(
+						golden,
+						out.Bytes(),
+						0644,
+					); err != nil {
+						t.Fatalf("couldn't update golden file: %s", err)
+					}
+				}
+				expected, err := ioutil.ReadFile(golden)
+				if err != nil {
+					t.Fatalf("couldn't read golden file: %s", err)
+				}
+				if out.String() != string(expected) {
+					t.Fatalf(
+						"unexpected diagnostics, expected:\n%s\ngot:\n%s",
+						expected,
+						out.String(),
+					)
+				}
+			})
+		}
+	}
+}
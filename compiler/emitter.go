@@ -0,0 +1,116 @@
+package compiler
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/romshark/TypeBook/export/avro"
+	"github.com/romshark/TypeBook/export/cypher"
+	"github.com/romshark/TypeBook/export/postgres"
+	"github.com/romshark/TypeBook/export/proto"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// Emitter generates output from validated document models
+type Emitter interface {
+	// Name returns the unique name the emitter is registered by
+	Name() string
+
+	// Emit writes the output generated from the given model
+	Emit(model *rend.Document, w io.Writer) error
+}
+
+// emitterFunc represents an emitter implemented by a function
+type emitterFunc struct {
+	name string
+	emit func(model *rend.Document, w io.Writer) error
+}
+
+// Name implements the Emitter interface
+func (e emitterFunc) Name() string {
+	return e.name
+}
+
+// Emit implements the Emitter interface
+func (e emitterFunc) Emit(model *rend.Document, w io.Writer) error {
+	return e.emit(model, w)
+}
+
+// NewEmitter creates a new emitter of the given name
+// implemented by the given function
+func NewEmitter(
+	name string,
+	emit func(model *rend.Document, w io.Writer) error,
+) Emitter {
+	return emitterFunc{name: name, emit: emit}
+}
+
+// generatorEmitter creates a new emitter of the given name
+// writing the output of the given generator
+func generatorEmitter(
+	name string,
+	generate func(model *rend.Document) ([]byte, error),
+) Emitter {
+	return NewEmitter(name, func(model *rend.Document, w io.Writer) error {
+		output, err := generate(model)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(append(output, '\n'))
+		return err
+	})
+}
+
+// builtinEmitters returns the emitters registered by default
+// using the default options of the generators.
+// Proto field numbers are assigned without a lock file
+func builtinEmitters() []Emitter {
+	return []Emitter{
+		NewEmitter("html", func(model *rend.Document, w io.Writer) error {
+			renderer, _, err := rend.New()
+			if err != nil {
+				return err
+			}
+			_, err = renderer.Render(model, w)
+			return err
+		}),
+		NewEmitter("site", func(model *rend.Document, w io.Writer) error {
+			renderer, _, err := rend.NewSite()
+			if err != nil {
+				return err
+			}
+			archive := rend.NewZipWriter(w)
+			if _, err := renderer.Render(model, archive); err != nil {
+				return err
+			}
+			return archive.Close()
+		}),
+		generatorEmitter("sql", func(model *rend.Document) ([]byte, error) {
+			return postgres.Generate(model, postgres.DefaultOptions())
+		}),
+		generatorEmitter("proto", func(model *rend.Document) ([]byte, error) {
+			schema, _, err := proto.Generate(model, proto.DefaultOptions(), nil)
+			return schema, err
+		}),
+		generatorEmitter("avro", func(model *rend.Document) ([]byte, error) {
+			return avro.Generate(model, avro.DefaultOptions())
+		}),
+		generatorEmitter("cypher", func(model *rend.Document) ([]byte, error) {
+			return cypher.Generate(model, cypher.DefaultOptions())
+		}),
+	}
+}
+
+// Emit writes the output of the emitter registered by the given name
+// generated from the model of the given result.
+// Returns an error if the result has errors
+func (c *Compiler) Emit(name string, result *Result, w io.Writer) error {
+	emitter, isRegistered := c.emitters[name]
+	if !isRegistered {
+		return fmt.Errorf("unknown emitter: '%s'", name)
+	}
+	if result == nil || result.Model == nil {
+		return fmt.Errorf("can't emit %s from an invalid document", name)
+	}
+	return emitter.Emit(result.Model, w)
+}
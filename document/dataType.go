@@ -5,8 +5,9 @@ import (
 	"regexp"
 )
 
-var plainDataTypePattern = regexp.MustCompile("^[A-Z][a-zA-Z_]*$")
-var listDataTypePattern = regexp.MustCompile("^List\\s*<([A-Z][a-zA-Z_]*)>$")
+var listDataTypePattern = regexp.MustCompile(
+	"^List\\s*<(" + qualifiedNamePattern + ")>$",
+)
 
 type DataType struct {
 	Name   string
@@ -26,7 +27,7 @@ func (d *DataType) FromBytes(buf []byte) error {
 		d.Name = listDataTypePattern.FindStringSubmatch(str)[1]
 		d.IsList = true
 		return nil
-	} else if plainDataTypePattern.Match(buf) {
+	} else if TypeNamePattern.Match(buf) {
 		d.Name = str
 		d.IsList = false
 		return nil
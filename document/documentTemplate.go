@@ -1,18 +1,75 @@
 package document
 
+// Examples lists example values of a type or a field
+type Examples []interface{}
+
+// Deprecation marks a type, field, enumeration value or relation
+// as deprecated
+type Deprecation struct {
+	Reason string `yaml:"reason"`
+
+	// Replacement references the replacing type ("Type")
+	// or type member ("Type.member")
+	Replacement string `yaml:"replacement"`
+
+	// RemovalVersion is the optional version the deprecated item
+	// is removed in
+	RemovalVersion string `yaml:"removal version"`
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// accepting the shorthand "deprecated: true" for a deprecation
+// without a notice besides the extended form
+func (d *Deprecation) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var isDeprecated bool
+	if err := unmarshal(&isDeprecated); err == nil {
+		// "deprecated: false" is reported by the structure verification
+		*d = Deprecation{}
+		return nil
+	}
+	type extended Deprecation
+	return unmarshal((*extended)(d))
+}
+
 type ScalarType struct {
-	Description string `yaml:"description"`
+	Description string            `yaml:"description"`
+	Kind        ScalarKind        `yaml:"kind"`
+	Constraints ScalarConstraints `yaml:"constraints"`
+	Examples    Examples          `yaml:"examples"`
+	Deprecated  *Deprecation      `yaml:"deprecated"`
+
+	// Index is the declaration index of the type
+	Index int `yaml:"-"`
+}
+
+// ScalarConstraints restricts the values a scalar type accepts
+type ScalarConstraints struct {
+	Pattern   string   `yaml:"pattern"`
+	Minimum   *float64 `yaml:"minimum"`
+	Maximum   *float64 `yaml:"maximum"`
+	MinLength *uint32  `yaml:"min length"`
+	MaxLength *uint32  `yaml:"max length"`
 }
 
 type EnumerationType struct {
 	Description string            `yaml:"description"`
-	Values      map[string]string `yaml:"values"`
+	Values      EnumerationValues `yaml:"values"`
+	Examples    Examples          `yaml:"examples"`
+	Deprecated  *Deprecation      `yaml:"deprecated"`
+
+	// Index is the declaration index of the type
+	Index int `yaml:"-"`
 }
 
 type TypeField struct {
-	Type        DataType `yaml:"type"`
-	Description string   `yaml:"description"`
-	Nullable    bool     `yaml:"nullable"`
+	Type        DataType     `yaml:"type"`
+	Description string       `yaml:"description"`
+	Nullable    bool         `yaml:"nullable"`
+	Examples    Examples     `yaml:"examples"`
+	Deprecated  *Deprecation `yaml:"deprecated"`
+
+	// Index is the declaration index of the field
+	Index int `yaml:"-"`
 }
 
 // Metadata maps the field names to a metadata field
@@ -27,26 +84,64 @@ type EntityRelation struct {
 	Type        string            `yaml:"type"`
 	Direction   RelationDirection `yaml:"direction"`
 	RelatedType string            `yaml:"related type"`
+	Examples    Examples          `yaml:"examples"`
+	Deprecated  *Deprecation      `yaml:"deprecated"`
+
+	// Index is the declaration index of the relation
+	Index int `yaml:"-"`
 }
 
 type CompositeType struct {
-	Description string   `yaml:"description"`
-	Metadata    Metadata `yaml:"meta"`
+	Description string       `yaml:"description"`
+	Metadata    Metadata     `yaml:"meta"`
+	Examples    Examples     `yaml:"examples"`
+	Deprecated  *Deprecation `yaml:"deprecated"`
+
+	// Index is the declaration index of the type
+	Index int `yaml:"-"`
 }
 
 type EntityType struct {
 	Description string          `yaml:"description"`
 	Metadata    Metadata        `yaml:"meta"`
 	Relations   EntityRelations `yaml:"relations"`
+	Examples    Examples        `yaml:"examples"`
+	Deprecated  *Deprecation    `yaml:"deprecated"`
+
+	// Index is the declaration index of the type
+	Index int `yaml:"-"`
 }
 
+// ScalarTypes maps type names to scalar types
+type ScalarTypes map[string]ScalarType
+
+// EnumerationTypes maps type names to enumeration types
+type EnumerationTypes map[string]EnumerationType
+
+// CompositeTypes maps type names to composite types
+type CompositeTypes map[string]CompositeType
+
+// EntityTypes maps type names to entity types
+type EntityTypes map[string]EntityType
+
 type Document struct {
-	Title            string                     `yaml:"title"`
-	Author           string                     `yaml:"author"`
-	Version          string                     `yaml:"version"`
-	Description      string                     `yaml:"description"`
-	ScalarTypes      map[string]ScalarType      `yaml:"scalar types"`
-	EnumerationTypes map[string]EnumerationType `yaml:"enumeration types"`
-	CompositeTypes   map[string]CompositeType   `yaml:"composite types"`
-	EntityTypes      map[string]EntityType      `yaml:"entity types"`
+	Title            string           `yaml:"title"`
+	Author           string           `yaml:"author"`
+	Version          string           `yaml:"version"`
+	Description      string           `yaml:"description"`
+	ScalarTypes      ScalarTypes      `yaml:"scalar types"`
+	EnumerationTypes EnumerationTypes `yaml:"enumeration types"`
+	CompositeTypes   CompositeTypes   `yaml:"composite types"`
+	EntityTypes      EntityTypes      `yaml:"entity types"`
+	Naming           NamingRules      `yaml:"naming"`
+
+	// Prelude enables the built-in library of well-known scalar types
+	Prelude bool `yaml:"prelude"`
+
+	// Source maps the declarations to their positions in the source
+	Source *SourceMap `yaml:"-"`
+
+	// Problems lists the structural problems of the document
+	// such as unknown and duplicate keys
+	Problems []Problem `yaml:"-"`
 }
@@ -0,0 +1,132 @@
+package document
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// EnumerationValueKind represents the kind of the value
+// of an enumeration item
+type EnumerationValueKind uint8
+
+const (
+	// NoValue represents items without a value
+	NoValue EnumerationValueKind = iota
+
+	// IntegerValue represents integer values
+	IntegerValue
+
+	// StringValue represents string values
+	StringValue
+)
+
+// String stringifies the value
+func (k EnumerationValueKind) String() string {
+	switch k {
+	case NoValue:
+		return "none"
+	case IntegerValue:
+		return "integer"
+	case StringValue:
+		return "string"
+	}
+	panic(fmt.Errorf(
+		"couldn't stringify invalid EnumerationValueKind value: %d",
+		k,
+	))
+}
+
+// EnumerationValues maps the enumeration items to their values
+type EnumerationValues map[string]EnumerationValue
+
+// EnumerationValue represents the value of an enumeration item.
+// It's declared either in the shorthand form (`Item: value`)
+// or in the extended form:
+//
+//	Item:
+//	  value: 1
+//	  description: "..."
+//	  aliases: [Other]
+//	  deprecated:
+//	    reason: "..."
+//
+// Items are also deprecated without a notice by `deprecated: true`
+type EnumerationValue struct {
+	Value       string       `yaml:"value"`
+	Description string       `yaml:"description"`
+	Aliases     []string     `yaml:"aliases"`
+	Deprecated  *Deprecation `yaml:"deprecated"`
+
+	// Kind is the kind of the value
+	Kind EnumerationValueKind `yaml:"-"`
+
+	// Index is the declaration index of the item
+	Index int `yaml:"-"`
+}
+
+// isScalarValue returns true if the given generically decoded value
+// is neither a mapping nor a list, otherwise returns false
+func isScalarValue(value interface{}) bool {
+	switch value.(type) {
+	case map[interface{}]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// enumerationValueOf returns the given decoded value as string
+// together with its kind. Values of other kinds are reported
+// by the structure verification and have no kind
+func enumerationValueOf(value interface{}) (string, EnumerationValueKind) {
+	switch value := value.(type) {
+	case nil:
+		return "", NoValue
+	case int:
+		return strconv.Itoa(value), IntegerValue
+	case int64:
+		return strconv.FormatInt(value, 10), IntegerValue
+	case uint64:
+		return strconv.FormatUint(value, 10), IntegerValue
+	case string:
+		return value, StringValue
+	case map[interface{}]interface{}, []interface{}:
+		return "", NoValue
+	}
+	return fmt.Sprint(value), NoValue
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+func (v *EnumerationValue) UnmarshalYAML(
+	unmarshal func(interface{}) error,
+) error {
+	// Try the shorthand form first
+	var shorthand interface{}
+	if err := unmarshal(&shorthand); err != nil {
+		return err
+	}
+	if isScalarValue(shorthand) {
+		*v = EnumerationValue{}
+		v.Value, v.Kind = enumerationValueOf(shorthand)
+		return nil
+	}
+
+	// Avoid recursing into this method
+	type extended struct {
+		Value       interface{}  `yaml:"value"`
+		Description string       `yaml:"description"`
+		Aliases     []string     `yaml:"aliases"`
+		Deprecated  *Deprecation `yaml:"deprecated"`
+	}
+	var val extended
+	err := unmarshal(&val)
+	if err != nil && !isTypeError(err) {
+		return err
+	}
+	*v = EnumerationValue{
+		Description: val.Description,
+		Aliases:     val.Aliases,
+		Deprecated:  val.Deprecated,
+	}
+	v.Value, v.Kind = enumerationValueOf(val.Value)
+	return err
+}
@@ -24,9 +24,24 @@ func New(buf []byte) (doc *Document, stats *Stats, err error) {
 
 	// Unmarshal YAML
 	startParsingInputFile := time.Now()
-	if err := yaml.Unmarshal(buf, doc); err != nil {
+	source := TagNullKeys(buf)
+	var tree yaml.MapSlice
+	if err := yaml.Unmarshal(source, &tree); err != nil {
 		return nil, nil, fmt.Errorf("couldn't parse file: %s", err)
 	}
+	var keys rawKeys
+	if err := yaml.Unmarshal(source, &keys); err != nil {
+		return nil, nil, fmt.Errorf("couldn't parse file: %s", err)
+	}
+	restoreKeys(tree, &keys)
+	doc.Problems = verifyStructure(tree)
+	if err := yaml.Unmarshal(source, doc); err != nil {
+		// Values of the wrong kind are reported as problems
+		if !isTypeError(err) || !hasKindProblems(doc.Problems) {
+			return nil, nil, fmt.Errorf("couldn't parse file: %s", err)
+		}
+	}
+	doc.Source = NewSourceMap(buf)
 	parsingInputFileDur := time.Since(startParsingInputFile)
 
 	return doc, &Stats{
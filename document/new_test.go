@@ -0,0 +1,193 @@
+package document
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestNewBoolLikeKeys verifies that keys YAML 1.1 resolves to booleans
+// such as "y" or "on" are verified by their raw names
+func TestNewBoolLikeKeys(t *testing.T) {
+	doc, _, err := New([]byte("title: Test\n" +
+		"composite types:\n" +
+		"  Point:\n" +
+		"    meta:\n" +
+		"      y:\n" +
+		"        type: Number\n" +
+		"      on:\n" +
+		"        type: Number\n" +
+		"  Flag:\n" +
+		"    meta:\n" +
+		"      n: 5\n"))
+	if err != nil {
+		t.Fatalf("couldn't parse: %s", err)
+	}
+	if len(doc.Problems) != 1 {
+		t.Fatalf("expected 1 problem, got %#v", doc.Problems)
+	}
+	problem := doc.Problems[0]
+	if problem.Kind != InvalidValueKind ||
+		strings.Join(problem.Path, ".") != "composite types.Flag.meta.n" {
+		t.Fatalf("unexpected problem: %#v", problem)
+	}
+	if _, isDeclared := doc.CompositeTypes["Point"].Metadata["on"]; !isDeclared {
+		t.Fatalf("expected field 'on' to be declared")
+	}
+}
+
+// TestNewDeclarationOrder verifies that bool-like keys
+// keep their declaration order
+func TestNewDeclarationOrder(t *testing.T) {
+	doc, _, err := New([]byte("title: Test\n" +
+		"composite types:\n" +
+		"  Point:\n" +
+		"    meta:\n" +
+		"      z:\n" +
+		"        type: Number\n" +
+		"      y:\n" +
+		"        type: Number\n" +
+		"      on:\n" +
+		"        type: Number\n"))
+	if err != nil {
+		t.Fatalf("couldn't parse: %s", err)
+	}
+	fields := doc.CompositeTypes["Point"].Metadata
+	for index, name := range []string{"z", "y", "on"} {
+		if fields[name].Index != index {
+			t.Fatalf(
+				"expected field '%s' at index %d, got %d",
+				name,
+				index,
+				fields[name].Index,
+			)
+		}
+	}
+}
+
+// TestNewDeprecatedShorthand verifies that types, fields, relations
+// and enumeration items are deprecated by the boolean shorthand
+func TestNewDeprecatedShorthand(t *testing.T) {
+	doc, _, err := New([]byte("title: Test\n" +
+		"scalar types:\n" +
+		"  Number:\n" +
+		"    kind: number\n" +
+		"    deprecated: true\n" +
+		"enumeration types:\n" +
+		"  Gender:\n" +
+		"    deprecated: true\n" +
+		"    values:\n" +
+		"      Male: 1\n" +
+		"      Unknown:\n" +
+		"        value: 2\n" +
+		"        deprecated: true\n" +
+		"composite types:\n" +
+		"  Point:\n" +
+		"    deprecated: true\n" +
+		"    meta:\n" +
+		"      x:\n" +
+		"        type: Number\n" +
+		"        deprecated: true\n" +
+		"entity types:\n" +
+		"  Movie:\n" +
+		"    deprecated: true\n" +
+		"    relations:\n" +
+		"      Sequels:\n" +
+		"        type: SequelOf\n" +
+		"        related type: Movie\n" +
+		"        deprecated: true\n"))
+	if err != nil {
+		t.Fatalf("couldn't parse: %s", err)
+	}
+	if len(doc.Problems) > 0 {
+		t.Fatalf("unexpected problems: %#v", doc.Problems)
+	}
+	gender := doc.EnumerationTypes["Gender"]
+	point := doc.CompositeTypes["Point"]
+	movie := doc.EntityTypes["Movie"]
+	for name, deprecation := range map[string]*Deprecation{
+		"Number":         doc.ScalarTypes["Number"].Deprecated,
+		"Gender":         gender.Deprecated,
+		"Gender.Unknown": gender.Values["Unknown"].Deprecated,
+		"Point":          point.Deprecated,
+		"Point.x":        point.Metadata["x"].Deprecated,
+		"Movie":          movie.Deprecated,
+		"Movie.Sequels":  movie.Relations["Sequels"].Deprecated,
+	} {
+		if deprecation == nil {
+			t.Fatalf("expected '%s' to be deprecated", name)
+		}
+	}
+	if gender.Values["Male"].Deprecated != nil {
+		t.Fatalf("expected 'Male' not to be deprecated")
+	}
+}
+
+// TestNewDeprecatedFalse verifies that "deprecated: false"
+// is reported instead of being mistaken for a deprecation
+func TestNewDeprecatedFalse(t *testing.T) {
+	doc, _, err := New([]byte("title: Test\n" +
+		"scalar types:\n" +
+		"  Number:\n" +
+		"    kind: number\n" +
+		"    deprecated: false\n"))
+	if err != nil {
+		t.Fatalf("couldn't parse: %s", err)
+	}
+	if len(doc.Problems) != 1 {
+		t.Fatalf("expected 1 problem, got %#v", doc.Problems)
+	}
+	problem := doc.Problems[0]
+	if problem.Kind != InvalidValueKind ||
+		strings.Join(problem.Path, ".") != "scalar types.Number.deprecated" {
+		t.Fatalf("unexpected problem: %#v", problem)
+	}
+}
+
+// TestNewNullLikeKeys verifies that keys YAML 1.1 resolves to null
+// such as "null" or "~" keep their raw names
+func TestNewNullLikeKeys(t *testing.T) {
+	doc, _, err := New([]byte("title: Test\n" +
+		"enumeration types:\n" +
+		"  Answer:\n" +
+		"    values:\n" +
+		"      Yes: 1\n" +
+		"      Null: 2\n" +
+		"composite types:\n" +
+		"  Value:\n" +
+		"    description: |\n" +
+		"      null: kept as is\n" +
+		"    meta:\n" +
+		"      null:\n" +
+		"        type: Number\n" +
+		"      ~:\n" +
+		"        type: Number\n" +
+		"      \"NULL\":\n" +
+		"        type: Number\n"))
+	if err != nil {
+		t.Fatalf("couldn't parse: %s", err)
+	}
+	if len(doc.Problems) > 0 {
+		t.Fatalf("unexpected problems: %#v", doc.Problems)
+	}
+
+	values := doc.EnumerationTypes["Answer"].Values
+	if value, isDeclared := values["Null"]; !isDeclared || value.Index != 1 {
+		t.Fatalf("expected item 'Null' at index 1, got %#v", values)
+	}
+
+	value := doc.CompositeTypes["Value"]
+	if value.Description != "null: kept as is\n" {
+		t.Fatalf("unexpected description: %q", value.Description)
+	}
+	for index, name := range []string{"null", "~", "NULL"} {
+		field, isDeclared := value.Metadata[name]
+		if !isDeclared || field.Index != index {
+			t.Fatalf(
+				"expected field '%s' at index %d, got %#v",
+				name,
+				index,
+				value.Metadata,
+			)
+		}
+	}
+}
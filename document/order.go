@@ -0,0 +1,98 @@
+package document
+
+import (
+	"reflect"
+
+	"github.com/go-yaml/yaml"
+)
+
+// isTypeError returns true if the given error is a type error
+// reported by go-YAML after decoding the remaining values,
+// otherwise returns false
+func isTypeError(err error) bool {
+	_, isTypeError := err.(*yaml.TypeError)
+	return isTypeError
+}
+
+// declarationOrder returns the declaration indexes of the raw keys
+// of the YAML mapping decoded by the given unmarshal function
+func declarationOrder(
+	unmarshal func(interface{}) error,
+) (map[string]int, error) {
+	keys, err := mappingKeys(unmarshal)
+	if err != nil {
+		return nil, err
+	}
+	order := make(map[string]int, len(keys))
+	for index, key := range keys {
+		order[key] = index
+	}
+	return order, nil
+}
+
+// unmarshalDeclarations decodes the YAML mapping into the map
+// the given pointer points to and sets the Index field of each value
+// to the declaration index of its key
+func unmarshalDeclarations(
+	unmarshal func(interface{}) error,
+	declarations interface{},
+) error {
+	order, err := declarationOrder(unmarshal)
+	if err != nil {
+		return err
+	}
+	// Type errors are reported after the valid items are decoded
+	if err = unmarshal(declarations); err != nil && !isTypeError(err) {
+		return err
+	}
+	mapping := reflect.ValueOf(declarations).Elem()
+	for _, key := range mapping.MapKeys() {
+		value := reflect.New(mapping.Type().Elem()).Elem()
+		value.Set(mapping.MapIndex(key))
+		value.FieldByName("Index").SetInt(int64(order[key.String()]))
+		mapping.SetMapIndex(key, value)
+	}
+	return err
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// preserving the declaration order of the scalar types
+func (m *ScalarTypes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalDeclarations(unmarshal, (*map[string]ScalarType)(m))
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// preserving the declaration order of the enumeration types
+func (m *EnumerationTypes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalDeclarations(unmarshal, (*map[string]EnumerationType)(m))
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// preserving the declaration order of the enumeration values
+func (m *EnumerationValues) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalDeclarations(unmarshal, (*map[string]EnumerationValue)(m))
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// preserving the declaration order of the composite types
+func (m *CompositeTypes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalDeclarations(unmarshal, (*map[string]CompositeType)(m))
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// preserving the declaration order of the entity types
+func (m *EntityTypes) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalDeclarations(unmarshal, (*map[string]EntityType)(m))
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// preserving the declaration order of the metadata fields
+func (m *Metadata) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalDeclarations(unmarshal, (*map[string]TypeField)(m))
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// preserving the declaration order of the entity relations
+func (m *EntityRelations) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	return unmarshalDeclarations(unmarshal, (*map[string]EntityRelation)(m))
+}
@@ -0,0 +1,78 @@
+package document
+
+import "fmt"
+
+// ProblemKind represents the kind of a structural problem
+type ProblemKind uint8
+
+const (
+	// UnknownKey represents keys the document format doesn't define
+	UnknownKey ProblemKind = iota
+
+	// DuplicateKey represents keys repeated in the same mapping
+	DuplicateKey
+
+	// InvalidValueKind represents values of the wrong kind
+	// such as a list where a mapping is expected
+	InvalidValueKind
+
+	// MissingKey represents required keys that are either
+	// missing or declared without a value
+	MissingKey
+)
+
+// String stringifies the value
+func (k ProblemKind) String() string {
+	switch k {
+	case UnknownKey:
+		return "unknown key"
+	case DuplicateKey:
+		return "duplicate key"
+	case InvalidValueKind:
+		return "invalid value kind"
+	case MissingKey:
+		return "missing key"
+	}
+	panic(fmt.Errorf("couldn't stringify invalid ProblemKind value: %d", k))
+}
+
+// Problem represents a structural problem of a document
+// that isn't detected by the lenient YAML decoding
+type Problem struct {
+	Kind ProblemKind
+
+	// Path is the path of the offending key
+	Path []string
+
+	// Occurrence is the 0-based occurrence of the offending key
+	// if the key of the path is repeated in the document
+	Occurrence int
+
+	// Suggestion is the known key closest to an unknown key,
+	// it's empty if there's no similar key
+	Suggestion string
+
+	// Expected and Actual describe the expected and the actual
+	// value kind such as "a mapping" and "a list"
+	Expected string
+	Actual   string
+}
+
+// Key returns the offending key
+func (p Problem) Key() string {
+	if len(p.Path) < 1 {
+		return ""
+	}
+	return p.Path[len(p.Path)-1]
+}
+
+// hasKindProblems returns true if any of the given problems
+// is an invalid value kind, otherwise returns false
+func hasKindProblems(problems []Problem) bool {
+	for _, problem := range problems {
+		if problem.Kind == InvalidValueKind {
+			return true
+		}
+	}
+	return false
+}
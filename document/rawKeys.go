@@ -0,0 +1,173 @@
+package document
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+
+	"github.com/go-yaml/yaml"
+)
+
+// keyRecorder collects the raw keys of the mapping decoded by mappingKeys.
+// go-YAML decodes mappings into maps of string keys in an undefined order
+// and resolves the keys of ordered mappings such as "y", "on" or "no"
+// to booleans, the raw keys are therefore recorded while they're decoded
+var keyRecorder struct {
+	sync.Mutex
+	keys []string
+}
+
+// recordedKey represents a mapping key recorded by the key recorder
+type recordedKey string
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// recording the raw text of the key
+func (k *recordedKey) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var text string
+	if err := unmarshal(&text); err != nil {
+		return err
+	}
+	*k = recordedKey(text)
+	keyRecorder.keys = append(keyRecorder.keys, text)
+	return nil
+}
+
+// skippedValue represents a mapping value that isn't decoded
+type skippedValue struct{}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// skipping the value
+func (*skippedValue) UnmarshalYAML(func(interface{}) error) error {
+	return nil
+}
+
+// mappingKeys returns the raw keys of the YAML mapping
+// decoded by the given unmarshal function in the document order
+// including repeated keys
+func mappingKeys(unmarshal func(interface{}) error) ([]string, error) {
+	keyRecorder.Lock()
+	defer keyRecorder.Unlock()
+
+	keyRecorder.keys = nil
+	var mapping map[recordedKey]skippedValue
+	err := unmarshal(&mapping)
+	keys := keyRecorder.keys
+	keyRecorder.keys = nil
+	if err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// rawKeys represents the raw keys of a YAML value
+// and of the values nested in it
+type rawKeys struct {
+	// keys are the keys of a mapping in the document order
+	keys []string
+
+	// values are the raw keys of the values of a mapping by key,
+	// the last value of a repeated key is kept
+	values map[string]*rawKeys
+
+	// items are the raw keys of the items of a list
+	items []*rawKeys
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+func (r *rawKeys) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&r.values); err == nil {
+		keys, err := mappingKeys(unmarshal)
+		if err != nil {
+			return err
+		}
+		r.keys = keys
+		return nil
+	}
+	r.values = nil
+	// Scalars are neither mappings nor lists
+	_ = unmarshal(&r.items)
+	return nil
+}
+
+// restoreKeys replaces the resolved keys of the mappings
+// of the given generically decoded YAML value by their raw keys
+func restoreKeys(node interface{}, raw *rawKeys) {
+	if raw == nil {
+		return
+	}
+	switch node := node.(type) {
+	case yaml.MapSlice:
+		if len(raw.keys) != len(node) {
+			return
+		}
+		for index := range node {
+			key := raw.keys[index]
+			node[index].Key = key
+			restoreKeys(node[index].Value, raw.values[key])
+		}
+	case []interface{}:
+		if len(raw.items) != len(node) {
+			return
+		}
+		for index, item := range node {
+			restoreKeys(item, raw.items[index])
+		}
+	}
+}
+
+// nullKeys are the spellings of null in YAML 1.1
+var nullKeys = map[string]bool{
+	"~":    true,
+	"null": true,
+	"Null": true,
+	"NULL": true,
+}
+
+// TagNullKeys returns a copy of the given YAML source with the block
+// mapping keys spelled like null, such as "null" or "~", explicitly
+// tagged as strings to keep their raw text. go-YAML passes neither null
+// values nor quoted values spelled like null to unmarshalers,
+// the raw text of such keys would be lost otherwise.
+// Lines and the positions of all other keys are kept unchanged
+func TagNullKeys(source []byte) []byte {
+	lines := bytes.Split(source, []byte("\n"))
+	tagged := make([][]byte, len(lines))
+	blockScalarIndent := -1
+	for index, rawLine := range lines {
+		tagged[index] = rawLine
+		line := strings.TrimRight(string(rawLine), " \t\r")
+		content := strings.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+		if content == "" || strings.HasPrefix(content, "#") {
+			continue
+		}
+
+		// Skip the lines of block scalars
+		if blockScalarIndent >= 0 {
+			if indent > blockScalarIndent {
+				continue
+			}
+			blockScalarIndent = -1
+		}
+
+		// Skip list item indicators
+		for strings.HasPrefix(content, "- ") || content == "-" {
+			trimmed := strings.TrimLeft(content[1:], " ")
+			indent += len(content) - len(trimmed)
+			content = trimmed
+		}
+
+		key, rest, isKey := parseKey(content)
+		if !isKey {
+			continue
+		}
+		if strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">") {
+			blockScalarIndent = indent
+		}
+		if !nullKeys[key] {
+			continue
+		}
+		tagged[index] = []byte(line[:indent] + "!!str " + string(rawLine[indent:]))
+	}
+	return bytes.Join(tagged, []byte("\n"))
+}
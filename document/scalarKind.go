@@ -0,0 +1,68 @@
+package document
+
+import "fmt"
+
+// ScalarKind represents the kind of values a scalar type accepts
+type ScalarKind string
+
+const (
+	// AnyKind represents scalar types accepting any kind of scalar value
+	AnyKind ScalarKind = ""
+
+	// StringKind represents textual scalar types
+	StringKind ScalarKind = "string"
+
+	// NumberKind represents numeric scalar types
+	NumberKind ScalarKind = "number"
+
+	// IntegerKind represents integral numeric scalar types
+	IntegerKind ScalarKind = "integer"
+
+	// BooleanKind represents boolean scalar types
+	BooleanKind ScalarKind = "boolean"
+
+	// TimeKind represents RFC3339 encoded datetime scalar types
+	TimeKind ScalarKind = "time"
+)
+
+// String stringifies the value
+func (sk ScalarKind) String() string {
+	if sk == AnyKind {
+		return "any"
+	}
+	return string(sk)
+}
+
+// FromBytes initializes the value from bytes
+func (sk *ScalarKind) FromBytes(buf []byte) error {
+	return sk.FromString(string(buf))
+}
+
+// FromString initializes the value from a string
+func (sk *ScalarKind) FromString(str string) error {
+	switch kind := ScalarKind(str); kind {
+	case AnyKind, StringKind, NumberKind, IntegerKind, BooleanKind, TimeKind:
+		*sk = kind
+		return nil
+	case "any":
+		*sk = AnyKind
+		return nil
+	}
+	return fmt.Errorf("invalid scalar kind: '%s'", str)
+}
+
+// UnmarshalJSON implements the Go JSON unmarshaller interface
+func (sk *ScalarKind) UnmarshalJSON(buf []byte) error {
+	return sk.FromBytes(buf)
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+func (sk *ScalarKind) UnmarshalYAML(
+	unmarshal func(interface{}) error,
+) error {
+	var val string
+	if err := unmarshal(&val); err != nil {
+		return err
+	}
+	return sk.FromString(val)
+}
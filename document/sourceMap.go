@@ -0,0 +1,231 @@
+package document
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+// Position represents a position in a source file
+type Position struct {
+	// Line is the 1-based line number
+	Line int
+
+	// Column is the 1-based column number
+	Column int
+}
+
+// SourceMap maps the paths of mapping keys and list items
+// of a YAML document to their positions in the source.
+// Flow style collections aren't mapped, values inside of them
+// resolve to the position of the closest mapped parent
+type SourceMap struct {
+	positions map[string]Position
+
+	// repetitions maps the paths of repeated keys to the positions
+	// of their occurrences following the first one
+	repetitions map[string][]Position
+
+	// paths maps line numbers to the paths of the innermost
+	// keys or list items declared on them
+	paths map[int][]string
+}
+
+// sourceMapKey returns the source map key of the given path
+func sourceMapKey(path []string) string {
+	return strings.Join(path, "\x00")
+}
+
+// sourceNode represents an open block collection node
+// during the scanning of a YAML source
+type sourceNode struct {
+	indent int
+	key    string
+	isItem bool
+	items  int
+}
+
+// parseKey returns the mapping key at the beginning of the given
+// line content, the rest of the line after the key and true
+// if the line starts with a mapping key, otherwise returns false
+func parseKey(content string) (key string, rest string, isKey bool) {
+	if content == "" {
+		return "", "", false
+	}
+	if quote := content[0]; quote == '"' || quote == '\'' {
+		end := strings.IndexByte(content[1:], quote)
+		if end < 0 {
+			return "", "", false
+		}
+		rest = strings.TrimLeft(content[end+2:], " ")
+		if !strings.HasPrefix(rest, ":") {
+			return "", "", false
+		}
+		return content[1 : end+1], strings.TrimSpace(rest[1:]), true
+	}
+	if content[0] == '{' || content[0] == '[' || content[0] == '#' {
+		return "", "", false
+	}
+	separator := strings.Index(content, ": ")
+	if separator < 0 {
+		if !strings.HasSuffix(content, ":") {
+			return "", "", false
+		}
+		separator = len(content) - 1
+	}
+	return strings.TrimSpace(content[:separator]),
+		strings.TrimSpace(content[separator+1:]),
+		true
+}
+
+// NewSourceMap scans the given YAML source and maps the paths
+// of its block mapping keys and block list items to their positions
+func NewSourceMap(source []byte) *SourceMap {
+	sourceMap := &SourceMap{
+		positions:   make(map[string]Position),
+		repetitions: make(map[string][]Position),
+		paths:       make(map[int][]string),
+	}
+	stack := make([]*sourceNode, 0)
+	blockScalarIndent := -1
+
+	path := func() []string {
+		keys := make([]string, len(stack))
+		for i, node := range stack {
+			keys[i] = node.key
+		}
+		return keys
+	}
+	push := func(node *sourceNode, line int) {
+		stack = append(stack, node)
+		sourceMap.paths[line] = path()
+		key := sourceMapKey(sourceMap.paths[line])
+		position := Position{Line: line, Column: node.indent + 1}
+		if _, isMapped := sourceMap.positions[key]; isMapped {
+			sourceMap.repetitions[key] = append(
+				sourceMap.repetitions[key],
+				position,
+			)
+			return
+		}
+		sourceMap.positions[key] = position
+	}
+	pop := func(shouldPop func(node *sourceNode) bool) {
+		for len(stack) > 0 && shouldPop(stack[len(stack)-1]) {
+			stack = stack[:len(stack)-1]
+		}
+	}
+
+	for index, rawLine := range bytes.Split(source, []byte("\n")) {
+		line := strings.TrimRight(string(rawLine), " \t\r")
+		content := strings.TrimLeft(line, " ")
+		indent := len(line) - len(content)
+		if content == "" || strings.HasPrefix(content, "#") {
+			continue
+		}
+		if content == "---" || content == "..." {
+			stack = stack[:0]
+			continue
+		}
+
+		// Skip the lines of block scalars
+		if blockScalarIndent >= 0 {
+			if indent > blockScalarIndent {
+				continue
+			}
+			blockScalarIndent = -1
+		}
+
+		// Open list items
+		for strings.HasPrefix(content, "- ") || content == "-" {
+			pop(func(node *sourceNode) bool {
+				return node.indent > indent ||
+					node.indent == indent && node.isItem
+			})
+			itemIndex := 0
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				itemIndex = parent.items
+				parent.items++
+			}
+			push(&sourceNode{
+				indent: indent,
+				key:    strconv.Itoa(itemIndex),
+				isItem: true,
+			}, index+1)
+
+			trimmed := strings.TrimLeft(content[1:], " ")
+			indent += len(content) - len(trimmed)
+			content = trimmed
+		}
+
+		key, rest, isKey := parseKey(content)
+		if !isKey {
+			continue
+		}
+		pop(func(node *sourceNode) bool {
+			return node.indent >= indent
+		})
+		push(&sourceNode{indent: indent, key: key}, index+1)
+
+		if strings.HasPrefix(rest, "|") || strings.HasPrefix(rest, ">") {
+			blockScalarIndent = indent
+		}
+	}
+	return sourceMap
+}
+
+// Lookup returns the position of the given path or the position
+// of its closest mapped parent and true if any was found,
+// otherwise returns false
+func (m *SourceMap) Lookup(path []string) (Position, bool) {
+	if m == nil {
+		return Position{}, false
+	}
+	for length := len(path); length > 0; length-- {
+		position, isMapped := m.positions[sourceMapKey(path[:length])]
+		if isMapped {
+			return position, true
+		}
+	}
+	return Position{}, false
+}
+
+// LookupOccurrence returns the position of the given 0-based occurrence
+// of the given path if its key is repeated, see Lookup
+func (m *SourceMap) LookupOccurrence(
+	path []string,
+	occurrence int,
+) (Position, bool) {
+	if m == nil {
+		return Position{}, false
+	}
+	if occurrence > 0 {
+		repetitions := m.repetitions[sourceMapKey(path)]
+		if occurrence <= len(repetitions) {
+			return repetitions[occurrence-1], true
+		}
+	}
+	return m.Lookup(path)
+}
+
+// Find returns the position of the given path
+// and true if it's mapped, otherwise returns false
+func (m *SourceMap) Find(path []string) (Position, bool) {
+	if m == nil {
+		return Position{}, false
+	}
+	position, isMapped := m.positions[sourceMapKey(path)]
+	return position, isMapped
+}
+
+// PathAt returns the path of the innermost key or list item declared
+// on the given 1-based line and true if there is any,
+// otherwise returns false
+func (m *SourceMap) PathAt(line int) ([]string, bool) {
+	if m == nil {
+		return nil, false
+	}
+	path, isMapped := m.paths[line]
+	return path, isMapped
+}
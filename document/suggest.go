@@ -0,0 +1,62 @@
+package document
+
+import "strings"
+
+// distance returns the Levenshtein distance between the given strings
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	previous := make([]int, len(rb)+1)
+	current := make([]int, len(rb)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		current[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			current[j] = min3(
+				previous[j]+1,
+				current[j-1]+1,
+				previous[j-1]+cost,
+			)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(rb)]
+}
+
+// min3 returns the smallest of the given integers
+func min3(a, b, c int) int {
+	if b < a {
+		a = b
+	}
+	if c < a {
+		a = c
+	}
+	return a
+}
+
+// Suggest returns the candidate most similar to the given name
+// ignoring the letter case. Returns an empty string if none
+// of the candidates is similar enough to be a likely misspelling
+func Suggest(name string, candidates []string) string {
+	if name == "" {
+		return ""
+	}
+	name = strings.ToLower(name)
+	maxDistance := len([]rune(name)) / 3
+	if maxDistance < 1 {
+		maxDistance = 1
+	}
+	suggestion := ""
+	for _, candidate := range candidates {
+		d := distance(name, strings.ToLower(candidate))
+		if d <= maxDistance {
+			suggestion, maxDistance = candidate, d-1
+		}
+	}
+	return suggestion
+}
@@ -0,0 +1,69 @@
+package document
+
+import (
+	"regexp"
+	"strings"
+)
+
+// identifierPattern matches the identifiers type names
+// and namespaces are composed of
+const identifierPattern = "[a-zA-Z_][a-zA-Z0-9_]*"
+
+// qualifiedNamePattern matches type names optionally qualified
+// by namespaces such as "Invoice" and "billing.Invoice"
+const qualifiedNamePattern = identifierPattern +
+	"(?:\\." + identifierPattern + ")*"
+
+// TypeNamePattern matches type references.
+// Type references are lexically less restrictive than the naming rules
+// to report violations as undefined types with suggestions
+var TypeNamePattern = regexp.MustCompile("^" + qualifiedNamePattern + "$")
+
+// DefaultTypeNameRule is the default naming rule of type names
+// without their namespaces
+const DefaultTypeNameRule = "^[A-Z][a-zA-Z0-9]*$"
+
+// DefaultNamespaceRule is the default naming rule of every namespace
+// of a qualified type name
+const DefaultNamespaceRule = "^[a-z][a-z0-9]*$"
+
+// NamingRules defines the regular expressions type names of every
+// category and namespaces must match. Empty rules fall back to
+// DefaultTypeNameRule and DefaultNamespaceRule respectively
+type NamingRules struct {
+	Namespaces       string `yaml:"namespaces"`
+	ScalarTypes      string `yaml:"scalar types"`
+	EnumerationTypes string `yaml:"enumeration types"`
+	CompositeTypes   string `yaml:"composite types"`
+	EntityTypes      string `yaml:"entity types"`
+	RelationTypes    string `yaml:"relation types"`
+}
+
+// SplitTypeName splits the given type name into its namespace
+// and its local name such as "billing" and "Invoice" for
+// "billing.Invoice". The namespace is empty if the name isn't qualified
+func SplitTypeName(typeName string) (namespace, localName string) {
+	separator := strings.LastIndex(typeName, ".")
+	if separator < 0 {
+		return "", typeName
+	}
+	return typeName[:separator], typeName[separator+1:]
+}
+
+// QualifyTypeName returns the name of the type referenced by the given
+// name from within the given namespace. References resolve to the type
+// declared in the innermost enclosing namespace, the name is returned
+// unchanged if none of the enclosing namespaces declares it
+func QualifyTypeName(
+	namespace string,
+	typeName string,
+	isDeclared func(typeName string) bool,
+) string {
+	for namespace != "" {
+		if qualified := namespace + "." + typeName; isDeclared(qualified) {
+			return qualified
+		}
+		namespace, _ = SplitTypeName(namespace)
+	}
+	return typeName
+}
@@ -0,0 +1,76 @@
+package document
+
+// decodeValue generically decodes the YAML value of the given
+// unmarshal function. Mappings are decoded into string keyed maps
+// keeping the raw text of keys YAML 1.1 resolves to other kinds,
+// such as "y" or "on" which would otherwise become booleans
+func decodeValue(unmarshal func(interface{}) error) (interface{}, error) {
+	var mapping map[string]rawValue
+	if err := unmarshal(&mapping); err == nil {
+		object := make(map[string]interface{}, len(mapping))
+		for key, value := range mapping {
+			object[key] = value.value
+		}
+		return object, nil
+	}
+	var list []rawValue
+	if err := unmarshal(&list); err == nil {
+		items := make([]interface{}, len(list))
+		for index, item := range list {
+			items[index] = item.value
+		}
+		return items, nil
+	}
+	// Scalars and mappings of complex keys are decoded as is
+	var value interface{}
+	if err := unmarshal(&value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// rawValue represents a generically decoded YAML value
+type rawValue struct {
+	value interface{}
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+func (v *rawValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	value, err := decodeValue(unmarshal)
+	if err != nil {
+		return err
+	}
+	v.value = value
+	return nil
+}
+
+// Object represents a generically decoded YAML mapping
+type Object map[string]interface{}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// keeping the raw keys of the object and of the objects nested in it
+func (o *Object) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var fields map[string]rawValue
+	if err := unmarshal(&fields); err != nil {
+		return err
+	}
+	*o = make(Object, len(fields))
+	for key, value := range fields {
+		(*o)[key] = value.value
+	}
+	return nil
+}
+
+// UnmarshalYAML implements the go-YAML unmarshaller interface
+// keeping the raw keys of the objects of the examples
+func (e *Examples) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var values []rawValue
+	if err := unmarshal(&values); err != nil {
+		return err
+	}
+	*e = make(Examples, len(values))
+	for index, value := range values {
+		(*e)[index] = value.value
+	}
+	return nil
+}
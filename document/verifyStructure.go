@@ -0,0 +1,303 @@
+package document
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/go-yaml/yaml"
+)
+
+var unmarshalerType = reflect.TypeOf((*yaml.Unmarshaler)(nil)).Elem()
+var enumerationValueType = reflect.TypeOf(EnumerationValue{})
+var deprecationType = reflect.TypeOf(Deprecation{})
+
+// requiredKeys maps the struct types to the keys
+// they can't be declared without
+var requiredKeys = map[reflect.Type][]string{
+	reflect.TypeOf(TypeField{}):      {"type"},
+	reflect.TypeOf(EntityRelation{}): {"type", "related type"},
+}
+
+// structureVerifier represents the state of a structure verification
+type structureVerifier struct {
+	problems []Problem
+
+	// occurrences counts the occurrences of the visited key paths
+	occurrences map[string]int
+}
+
+// verifyStructure verifies the given generically decoded YAML document
+// against the known keys and value kinds of the document format.
+// The keys are visited in the document order to resolve the occurrences
+// of repeated keys the same way the source map does
+func verifyStructure(tree yaml.MapSlice) []Problem {
+	v := &structureVerifier{occurrences: make(map[string]int)}
+	v.verify(tree, reflect.TypeOf(Document{}), []string{}, 0)
+	return v.problems
+}
+
+// kindOf describes the kind of the given decoded value
+func kindOf(node interface{}) string {
+	switch node.(type) {
+	case nil:
+		return "null"
+	case yaml.MapSlice:
+		return "a mapping"
+	case []interface{}:
+		return "a list"
+	case bool:
+		return "a boolean"
+	case int, int64:
+		if reflect.ValueOf(node).Int() < 0 {
+			return "a negative integer"
+		}
+		return "an integer"
+	case uint, uint64:
+		return "an integer"
+	case float64:
+		return "a number"
+	case string:
+		return "a string"
+	}
+	return "a scalar"
+}
+
+// isScalar returns true if the given decoded value is a scalar,
+// otherwise returns false
+func isScalar(node interface{}) bool {
+	switch node.(type) {
+	case yaml.MapSlice, []interface{}:
+		return false
+	}
+	return true
+}
+
+// knownKeys returns the keys of the fields of the given struct type
+// in the declaration order
+func knownKeys(t reflect.Type) ([]string, map[string]reflect.Type) {
+	keys := make([]string, 0, t.NumField())
+	types := make(map[string]reflect.Type, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key := strings.Split(field.Tag.Get("yaml"), ",")[0]
+		if key == "-" || field.PkgPath != "" {
+			continue
+		}
+		if key == "" {
+			key = strings.ToLower(field.Name)
+		}
+		keys = append(keys, key)
+		types[key] = field.Type
+	}
+	return keys, types
+}
+
+// addKindProblem adds an invalid value kind problem
+func (v *structureVerifier) addKindProblem(
+	node interface{},
+	expected string,
+	path []string,
+	occurrence int,
+) {
+	v.problems = append(v.problems, Problem{
+		Kind:       InvalidValueKind,
+		Path:       path,
+		Occurrence: occurrence,
+		Expected:   expected,
+		Actual:     kindOf(node),
+	})
+}
+
+// verify verifies the given value of the given expected type
+// declared by the key of the given path
+func (v *structureVerifier) verify(
+	node interface{},
+	t reflect.Type,
+	path []string,
+	occurrence int,
+) {
+	if node == nil {
+		// Null values are decoded as zero values
+		return
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	switch {
+	case t == enumerationValueType:
+		// Enumeration values are declared either in the shorthand
+		// or in the extended form
+		if isScalar(node) {
+			v.verifyEnumerationItemValue(node, path, occurrence)
+			return
+		}
+		if _, isMapping := node.(yaml.MapSlice); !isMapping {
+			v.addKindProblem(
+				node,
+				"an integer, a string or a mapping",
+				path,
+				occurrence,
+			)
+			return
+		}
+		v.verifyMapping(node, t, path, occurrence)
+
+	case t == deprecationType:
+		// Deprecations are declared either by "deprecated: true"
+		// or in the extended form
+		if isDeprecated, isFlag := node.(bool); isFlag && isDeprecated {
+			return
+		}
+		if _, isMapping := node.(yaml.MapSlice); !isMapping {
+			v.addKindProblem(node, "true or a mapping", path, occurrence)
+			return
+		}
+		v.verifyMapping(node, t, path, occurrence)
+
+	case t.Kind() != reflect.Map && t.Kind() != reflect.Slice &&
+		reflect.PtrTo(t).Implements(unmarshalerType):
+		// Values of custom types are parsed from strings
+		if !isScalar(node) {
+			v.addKindProblem(node, "a string", path, occurrence)
+		}
+
+	case t.Kind() == reflect.Struct, t.Kind() == reflect.Map:
+		v.verifyMapping(node, t, path, occurrence)
+
+	case t.Kind() == reflect.Slice:
+		items, isList := node.([]interface{})
+		if !isList {
+			v.addKindProblem(node, "a list", path, occurrence)
+			return
+		}
+		for index, item := range items {
+			v.verify(
+				item,
+				t.Elem(),
+				append(path[:len(path):len(path)], strconv.Itoa(index)),
+				occurrence,
+			)
+		}
+
+	case t.Kind() == reflect.Bool:
+		if _, isBool := node.(bool); !isBool {
+			v.addKindProblem(node, "a boolean", path, occurrence)
+		}
+
+	case t.Kind() == reflect.String:
+		if !isScalar(node) {
+			v.addKindProblem(node, "a string", path, occurrence)
+		}
+
+	case t.Kind() == reflect.Float32, t.Kind() == reflect.Float64:
+		switch node.(type) {
+		case int, int64, uint, uint64, float64:
+		default:
+			v.addKindProblem(node, "a number", path, occurrence)
+		}
+
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Uint64:
+		isUnsigned := t.Kind() >= reflect.Uint
+		switch value := reflect.ValueOf(node); value.Kind() {
+		case reflect.Int, reflect.Int64:
+			if isUnsigned && value.Int() < 0 {
+				v.addKindProblem(node, "a non-negative integer", path, occurrence)
+			}
+		case reflect.Uint, reflect.Uint64:
+		default:
+			v.addKindProblem(node, "an integer", path, occurrence)
+		}
+	}
+}
+
+// verifyEnumerationItemValue verifies the given value
+// of an enumeration item is either an integer or a string
+func (v *structureVerifier) verifyEnumerationItemValue(
+	node interface{},
+	path []string,
+	occurrence int,
+) {
+	switch node.(type) {
+	case nil, int, int64, uint64, string:
+		return
+	}
+	v.addKindProblem(node, "an integer or a string", path, occurrence)
+}
+
+// verifyMapping verifies the keys and values of the given mapping
+// decoded into either a struct or a map of the given type
+func (v *structureVerifier) verifyMapping(
+	node interface{},
+	t reflect.Type,
+	path []string,
+	occurrence int,
+) {
+	items, isMapping := node.(yaml.MapSlice)
+	if !isMapping {
+		v.addKindProblem(node, "a mapping", path, occurrence)
+		return
+	}
+
+	var keys []string
+	var fieldTypes map[string]reflect.Type
+	if t.Kind() == reflect.Struct {
+		keys, fieldTypes = knownKeys(t)
+	}
+
+	declared := make(map[string]bool, len(items))
+	defined := make(map[string]bool, len(items))
+	for _, item := range items {
+		key := fmt.Sprint(item.Key)
+		itemPath := append(path[:len(path):len(path)], key)
+		pathKey := sourceMapKey(itemPath)
+		itemOccurrence := v.occurrences[pathKey]
+		v.occurrences[pathKey]++
+
+		if declared[key] {
+			v.problems = append(v.problems, Problem{
+				Kind:       DuplicateKey,
+				Path:       itemPath,
+				Occurrence: itemOccurrence,
+			})
+		}
+		declared[key] = true
+		if item.Value != nil {
+			defined[key] = true
+		}
+
+		var valueType reflect.Type
+		if fieldTypes == nil {
+			valueType = t.Elem()
+		} else {
+			fieldType, isKnown := fieldTypes[key]
+			if !isKnown {
+				v.problems = append(v.problems, Problem{
+					Kind:       UnknownKey,
+					Path:       itemPath,
+					Occurrence: itemOccurrence,
+					Suggestion: Suggest(key, keys),
+				})
+				continue
+			}
+			valueType = fieldType
+		}
+		if t == enumerationValueType && key == "value" {
+			v.verifyEnumerationItemValue(item.Value, itemPath, itemOccurrence)
+			continue
+		}
+		v.verify(item.Value, valueType, itemPath, itemOccurrence)
+	}
+
+	for _, key := range requiredKeys[t] {
+		if !defined[key] {
+			v.problems = append(v.problems, Problem{
+				Kind:       MissingKey,
+				Path:       append(path[:len(path):len(path)], key),
+				Occurrence: occurrence,
+			})
+		}
+	}
+}
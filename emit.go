@@ -0,0 +1,92 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/romshark/TypeBook/compiler"
+)
+
+// emit writes the output of a registered emitter
+// generated from a schema document
+func emit(args []string) {
+	flags := flag.NewFlagSet("emit", flag.ExitOnError)
+	outputFilePath := flags.String(
+		"o",
+		"",
+		"Output file path (defaults to stdout)",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	emitterName := flags.String(
+		"e",
+		"html",
+		"Name of the emitter",
+	)
+	flags.StringVar(
+		buildTimestamp,
+		"build-time",
+		"",
+		"Build time in Unix seconds, \"now\" or \"none\"",
+	)
+	order := flags.String(
+		"order",
+		"declaration",
+		"Order of types, fields and values (declaration or alphabetical)",
+	)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		log.Fatalf("Missing schema file path")
+	}
+
+	build, err := buildTime()
+	if err != nil {
+		log.Fatalf("Couldn't determine build time: %s", err)
+	}
+	options := compiler.Options{BuildTime: build}
+	if err := options.Order.FromString(*order); err != nil {
+		log.Fatalf("Invalid order: %s", err)
+	}
+	c := compiler.New(options)
+	if _, isRegistered := c.Emitter(*emitterName); !isRegistered {
+		log.Fatalf(
+			"Unknown emitter '%s', available emitters: %s",
+			*emitterName,
+			strings.Join(c.Emitters(), ", "),
+		)
+	}
+
+	result, err := c.CompileFile(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("Couldn't compile document: %s", err)
+	}
+	// Keep stdout reserved for the output
+	if writeDiagnostics(os.Stderr, *format, result.Diagnostics) {
+		os.Exit(1)
+	}
+
+	var buf bytes.Buffer
+	if err := c.Emit(*emitterName, result, &buf); err != nil {
+		log.Fatalf("Couldn't emit %s: %s", *emitterName, err)
+	}
+
+	if *outputFilePath == "" {
+		os.Stdout.Write(buf.Bytes())
+		return
+	}
+	if err := ioutil.WriteFile(
+		*outputFilePath,
+		buf.Bytes(),
+		0644,
+	); err != nil {
+		log.Fatalf("Couldn't write output to file: %s", err)
+	}
+}
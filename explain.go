@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/romshark/TypeBook/rend"
+)
+
+// explain prints the explanation of an error code
+// or lists all error codes if none is given
+func explain(args []string) {
+	flags := flag.NewFlagSet("explain", flag.ExitOnError)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		for _, explanation := range rend.Catalogue() {
+			fmt.Printf(
+				"%-32s %s\n",
+				explanation.Code,
+				explanation.Summary,
+			)
+		}
+		return
+	}
+
+	explanation, isDocumented := rend.Explain(flags.Arg(0))
+	if !isDocumented {
+		log.Printf("Unknown error code: '%s'", flags.Arg(0))
+		log.Printf("Run 'typebook explain' to list all error codes")
+		os.Exit(1)
+	}
+	fmt.Printf(
+		"%s (%s)\n\n%s.\n\n%s\n\nExample:\n\n",
+		explanation.Code,
+		explanation.Severity,
+		explanation.Summary,
+		explanation.Explanation,
+	)
+	for _, line := range strings.Split(
+		strings.TrimRight(explanation.Example, "\n"),
+		"\n",
+	) {
+		fmt.Printf("    %s\n", line)
+	}
+}
@@ -0,0 +1,314 @@
+// Package avro generates Avro schemas from document models
+package avro
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/export"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// kindTypes maps scalar kinds to the primitive types
+// scalar types of the kind are encoded as.
+// Values of scalar types accepting any kind are encoded as JSON strings
+var kindTypes = map[document.ScalarKind]interface{}{
+	document.AnyKind:     "string",
+	document.StringKind:  "string",
+	document.NumberKind:  "double",
+	document.IntegerKind: "long",
+	document.BooleanKind: "boolean",
+	document.TimeKind:    logicalType{"long", "timestamp-millis"},
+}
+
+// logicalTypes maps the supported logical types to their primitive types
+var logicalTypes = map[string]string{
+	"date":                   "int",
+	"time-millis":            "int",
+	"time-micros":            "long",
+	"timestamp-millis":       "long",
+	"timestamp-micros":       "long",
+	"local-timestamp-millis": "long",
+	"local-timestamp-micros": "long",
+	"uuid":                   "string",
+}
+
+// namePattern matches valid names
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// invalidNameCharPattern matches the characters not allowed in names
+var invalidNameCharPattern = regexp.MustCompile(`[^A-Za-z0-9_]+`)
+
+// namespacePattern matches valid namespaces
+var namespacePattern = regexp.MustCompile(
+	`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)*$`,
+)
+
+// logicalType represents a primitive type annotated with a logical type
+type logicalType struct {
+	Type        string `json:"type"`
+	LogicalType string `json:"logicalType"`
+}
+
+// arrayType represents an array schema
+type arrayType struct {
+	Type  string      `json:"type"`
+	Items interface{} `json:"items"`
+}
+
+// enumType represents an enum schema
+type enumType struct {
+	Type      string   `json:"type"`
+	Name      string   `json:"name"`
+	Namespace string   `json:"namespace,omitempty"`
+	Doc       string   `json:"doc,omitempty"`
+	Symbols   []string `json:"symbols"`
+}
+
+// field represents a field of a record schema
+type field struct {
+	Name    string          `json:"name"`
+	Doc     string          `json:"doc,omitempty"`
+	Type    interface{}     `json:"type"`
+	Default json.RawMessage `json:"default,omitempty"`
+}
+
+// recordType represents a record schema
+type recordType struct {
+	Type      string  `json:"type"`
+	Name      string  `json:"name"`
+	Namespace string  `json:"namespace,omitempty"`
+	Doc       string  `json:"doc,omitempty"`
+	Fields    []field `json:"fields"`
+}
+
+// Record represents the self-contained record schema of a type
+type Record struct {
+	Name   string
+	Schema []byte
+}
+
+// generator represents the state of a schema generation
+type generator struct {
+	doc       *rend.Document
+	options   Options
+	namespace string
+
+	// defined marks the named types that were already defined
+	// and are referenced by name
+	defined map[string]bool
+}
+
+// sanitize replaces the characters not allowed in names by underscores
+func sanitize(name string) string {
+	name = strings.Trim(invalidNameCharPattern.ReplaceAllString(name, "_"), "_")
+	if !namePattern.MatchString(name) {
+		name = "_" + name
+	}
+	return name
+}
+
+// Symbols returns the symbols of the given enumeration type
+// sanitized to valid names such as "Science_fiction" for
+// "Science fiction" in the document order
+func Symbols(doc *rend.Document, t *rend.EnumerationType) []string {
+	values := doc.OrderedEnumerationValues(t)
+	symbols := make([]string, len(values))
+	taken := make(map[string]bool, len(values))
+	for i, value := range values {
+		symbol := sanitize(value.Name)
+		unique := symbol
+		for suffix := 2; taken[unique]; suffix++ {
+			unique = fmt.Sprintf("%s_%d", symbol, suffix)
+		}
+		taken[unique] = true
+		symbols[i] = unique
+	}
+	return symbols
+}
+
+// newGenerator returns a new generator after verifying the options
+func newGenerator(doc *rend.Document, options Options) (*generator, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("missing document model")
+	}
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = export.Namespace(doc.Metadata.Title)
+	}
+	if !namespacePattern.MatchString(namespace) {
+		return nil, fmt.Errorf("invalid namespace: '%s'", namespace)
+	}
+
+	scalarNames := make([]string, 0, len(options.LogicalTypes))
+	for name := range options.LogicalTypes {
+		scalarNames = append(scalarNames, name)
+	}
+	sort.Strings(scalarNames)
+	for _, name := range scalarNames {
+		if _, isScalar := doc.ScalarTypes[name]; !isScalar {
+			return nil, fmt.Errorf(
+				"logical type map references undefined scalar type '%s'",
+				name,
+			)
+		}
+		logical := options.LogicalTypes[name]
+		if _, isSupported := logicalTypes[logical]; !isSupported {
+			return nil, fmt.Errorf(
+				"unsupported logical type '%s' of scalar type '%s'",
+				logical,
+				name,
+			)
+		}
+	}
+
+	return &generator{
+		doc:       doc,
+		options:   options,
+		namespace: namespace,
+		defined:   make(map[string]bool),
+	}, nil
+}
+
+// Generate returns a JSON array of the enum schemas of the enumeration
+// types and the record schemas of the composite and entity types of the
+// given validated document model. Each named type is defined once and
+// referenced by name afterwards
+func Generate(doc *rend.Document, options Options) ([]byte, error) {
+	g, err := newGenerator(doc, options)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make([]interface{}, 0, doc.TotalTypes())
+	for _, t := range doc.OrderedEnumerationTypes() {
+		schemas = append(schemas, g.typeSchema(t))
+	}
+	for _, t := range doc.OrderedCompositeTypes() {
+		if !g.defined[t.TypeName] {
+			schemas = append(schemas, g.typeSchema(t))
+		}
+	}
+	for _, t := range doc.OrderedEntityTypes() {
+		schemas = append(schemas, g.typeSchema(t))
+	}
+	return json.MarshalIndent(schemas, "", "  ")
+}
+
+// Records returns the self-contained record schemas of the composite
+// and entity types of the given validated document model
+// defining the named types they depend on inline
+func Records(doc *rend.Document, options Options) ([]Record, error) {
+	g, err := newGenerator(doc, options)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]rend.ComplexType, 0, doc.TotalCompositeTypes()+
+		doc.TotalEntityTypes())
+	for _, t := range doc.OrderedCompositeTypes() {
+		types = append(types, t)
+	}
+	for _, t := range doc.OrderedEntityTypes() {
+		types = append(types, t)
+	}
+
+	records := make([]Record, len(types))
+	for i, t := range types {
+		g.defined = make(map[string]bool)
+		schema, err := json.MarshalIndent(g.typeSchema(t), "", "  ")
+		if err != nil {
+			return nil, err
+		}
+		records[i] = Record{Name: t.Name(), Schema: schema}
+	}
+	return records, nil
+}
+
+// typeNamespace returns the namespace of the given named type
+// such as "movies.billing" for "billing.Invoice" nested
+// in the generated namespace "movies"
+func (g *generator) typeNamespace(t rend.AbstractType) string {
+	if namespace := rend.Namespace(t); namespace != "" {
+		return g.namespace + "." + namespace
+	}
+	return g.namespace
+}
+
+// fullName returns the fully qualified name of the given named type
+// such as "movies.billing.Invoice" for "billing.Invoice"
+func (g *generator) fullName(t rend.AbstractType) string {
+	return g.typeNamespace(t) + "." + rend.LocalName(t)
+}
+
+// typeSchema returns the schema of the given type
+// or its name if it's already defined
+func (g *generator) typeSchema(t rend.AbstractType) interface{} {
+	// Named types are defined at the first occurrence
+	// and referenced by their full name afterwards
+	if g.defined[t.Name()] {
+		return g.fullName(t)
+	}
+	switch t := t.(type) {
+	case *rend.ScalarType:
+		if logical, isMapped := g.options.LogicalTypes[t.TypeName]; isMapped {
+			return logicalType{logicalTypes[logical], logical}
+		}
+		if logical, isMapped := t.Mappings["avro"]; isMapped {
+			return logicalType{logicalTypes[logical], logical}
+		}
+		return kindTypes[t.Kind]
+
+	case *rend.EnumerationType:
+		g.defined[t.TypeName] = true
+		return enumType{
+			Type:      "enum",
+			Name:      rend.LocalName(t),
+			Namespace: g.typeNamespace(t),
+			Doc:       strings.TrimSpace(t.Description),
+			Symbols:   Symbols(g.doc, t),
+		}
+
+	case *rend.CompositeType:
+		g.defined[t.TypeName] = true
+		return g.record(t, t.Description)
+
+	case *rend.EntityType:
+		g.defined[t.TypeName] = true
+		return g.record(t, t.Description)
+	}
+	return "string"
+}
+
+// record returns the record schema of the given type
+func (g *generator) record(
+	t rend.ComplexType,
+	description string,
+) recordType {
+	record := recordType{
+		Type:      "record",
+		Name:      rend.LocalName(t),
+		Namespace: g.typeNamespace(t),
+		Doc:       strings.TrimSpace(description),
+		Fields:    make([]field, 0, t.TotalMetadataFields()),
+	}
+	for _, typedField := range g.doc.OrderedFields(t) {
+		fieldType := g.typeSchema(typedField.Type)
+		if typedField.IsList {
+			fieldType = arrayType{Type: "array", Items: fieldType}
+		}
+		f := field{
+			Name: sanitize(typedField.Name),
+			Doc:  strings.TrimSpace(typedField.Description),
+			Type: fieldType,
+		}
+		if typedField.Nullable {
+			f.Type = []interface{}{"null", fieldType}
+			f.Default = json.RawMessage("null")
+		}
+		record.Fields = append(record.Fields, f)
+	}
+	return record
+}
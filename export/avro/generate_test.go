@@ -0,0 +1,69 @@
+package avro
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// update overwrites the golden files with the generated schemas
+var update = flag.Bool("update", false, "update the golden files")
+
+// loadModel returns the document model of the given schema file
+func loadModel(t *testing.T, path string) *rend.Document {
+	t.Helper()
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("couldn't read schema: %s", err)
+	}
+	doc, _, err := document.New(source)
+	if err != nil {
+		t.Fatalf("couldn't parse schema: %s", err)
+	}
+	model, errs, _, err := rend.NewModel(doc, time.Time{})
+	if err != nil || errs.HasErrors() {
+		t.Fatalf("couldn't initialize model: %s %#v", err, errs)
+	}
+	return model
+}
+
+// TestGenerate verifies the generated schemas
+// against the golden files in testdata
+func TestGenerate(t *testing.T) {
+	for name, path := range map[string]string{
+		"example":    "../../example.yml",
+		"namespaced": "../testdata/namespaced.yml",
+		"recursive":  "../testdata/recursive.yml",
+	} {
+		t.Run(name, func(t *testing.T) {
+			schema, err := Generate(loadModel(t, path), DefaultOptions())
+			if err != nil {
+				t.Fatalf("couldn't generate: %s", err)
+			}
+			schema = append(schema, '\n')
+
+			golden := filepath.Join("testdata", name+".json")
+			if *update {
+				if err := ioutil.WriteFile(golden, schema, 0644); err != nil {
+					t.Fatalf("couldn't update golden file: %s", err)
+				}
+			}
+			expected, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("couldn't read golden file: %s", err)
+			}
+			if string(schema) != string(expected) {
+				t.Fatalf(
+					"unexpected schema, expected:\n%s\ngot:\n%s",
+					expected,
+					schema,
+				)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+package avro
+
+// Options represents the options of the Avro schema generator
+type Options struct {
+	// Namespace is the namespace of the named types,
+	// it's derived from the document title if empty
+	Namespace string
+
+	// LogicalTypes maps scalar type names to logical types
+	// such as "uuid" or "date" overriding the types derived
+	// from the kind of the scalar type
+	LogicalTypes map[string]string
+}
+
+// DefaultOptions returns the default generator options
+func DefaultOptions() Options {
+	return Options{LogicalTypes: make(map[string]string)}
+}
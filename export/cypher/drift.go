@@ -0,0 +1,201 @@
+package cypher
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+
+	"github.com/romshark/TypeBook/rend"
+)
+
+// databaseLocation is the location of drift diagnostics
+// about elements not declared by the document model
+var databaseLocation = rend.Location{Description: "database schema"}
+
+// graphElement represents a node or relationship of a schema
+// visualization. Exports of different tools name the identifiers
+// and endpoints differently
+type graphElement struct {
+	Identity         interface{}            `json:"identity"`
+	ID               interface{}            `json:"id"`
+	ElementID        interface{}            `json:"elementId"`
+	Labels           []string               `json:"labels"`
+	Type             string                 `json:"type"`
+	Start            interface{}            `json:"start"`
+	StartNode        interface{}            `json:"startNode"`
+	StartNodeElement interface{}            `json:"startNodeElementId"`
+	End              interface{}            `json:"end"`
+	EndNode          interface{}            `json:"endNode"`
+	EndNodeElement   interface{}            `json:"endNodeElementId"`
+	Properties       map[string]interface{} `json:"properties"`
+}
+
+// key returns the first defined of the given identifiers
+func key(identifiers ...interface{}) string {
+	for _, identifier := range identifiers {
+		if identifier != nil {
+			return fmt.Sprint(identifier)
+		}
+	}
+	return ""
+}
+
+// name returns the label or relationship type of the element
+func (e graphElement) name() string {
+	if len(e.Labels) > 0 {
+		return e.Labels[0]
+	}
+	if e.Type != "" {
+		return e.Type
+	}
+	if name, isString := e.Properties["name"].(string); isString {
+		return name
+	}
+	return ""
+}
+
+// visualization represents the output of db.schema.visualization()
+type visualization struct {
+	Nodes         []graphElement `json:"nodes"`
+	Relationships []graphElement `json:"relationships"`
+}
+
+// parseVisualization parses a JSON export of the output
+// of db.schema.visualization() which is either a single record
+// or an array of records
+func parseVisualization(buf []byte) (visualization, error) {
+	var records []visualization
+	if err := json.Unmarshal(buf, &records); err != nil {
+		var record visualization
+		if err := json.Unmarshal(buf, &record); err != nil {
+			return visualization{}, fmt.Errorf(
+				"couldn't parse schema visualization: %s",
+				err,
+			)
+		}
+		records = []visualization{record}
+	}
+	merged := visualization{}
+	for _, record := range records {
+		merged.Nodes = append(merged.Nodes, record.Nodes...)
+		merged.Relationships = append(
+			merged.Relationships,
+			record.Relationships...,
+		)
+	}
+	return merged, nil
+}
+
+// DriftFile reads the schema visualization located at the given path.
+// See Drift
+func DriftFile(doc *rend.Document, path string) (rend.ModelErrors, error) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read file: %s", err)
+	}
+	return Drift(doc, buf)
+}
+
+// Drift returns schema drift warnings about the labels and relationship
+// types of the given validated document model missing from the database
+// schema and the ones the model doesn't declare. The database schema is
+// read from a JSON export of the output of db.schema.visualization()
+func Drift(doc *rend.Document, buf []byte) (rend.ModelErrors, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("missing document model")
+	}
+	schema, err := parseVisualization(buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var drift rend.ModelErrors
+
+	// Compare the labels
+	labels := make(map[string]string, len(schema.Nodes))
+	for _, node := range schema.Nodes {
+		labels[key(node.Identity, node.ID, node.ElementID)] = node.name()
+	}
+	present := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		present[label] = true
+	}
+	for _, entity := range doc.OrderedEntityTypes() {
+		if !present[entity.TypeName] {
+			drift.AddWarnSchemaDrift(
+				fmt.Sprintf("label '%s' is missing from the database", entity.TypeName),
+				rend.DeclarationLocation(entity, fmt.Sprintf(
+					"entity type '%s'",
+					entity.TypeName,
+				)),
+			)
+		}
+	}
+	undeclared := make([]string, 0)
+	for label := range present {
+		if _, isDeclared := doc.EntityTypes[label]; !isDeclared && label != "" {
+			undeclared = append(undeclared, label)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, label := range undeclared {
+		drift.AddWarnSchemaDrift(
+			fmt.Sprintf("label '%s' isn't declared by the schema", label),
+			databaseLocation,
+		)
+	}
+
+	// Compare the relationship types and their endpoints
+	patterns := make(map[string]bool, len(schema.Relationships))
+	for _, relationship := range schema.Relationships {
+		patterns[fmt.Sprintf(
+			"(:%s)-[:%s]->(:%s)",
+			labels[key(
+				relationship.Start,
+				relationship.StartNode,
+				relationship.StartNodeElement,
+			)],
+			relationship.name(),
+			labels[key(
+				relationship.End,
+				relationship.EndNode,
+				relationship.EndNodeElement,
+			)],
+		)] = true
+	}
+	declared := make(map[string]bool)
+	for _, relation := range Relations(doc) {
+		pattern := fmt.Sprintf(
+			"(:%s)-[:%s]->(:%s)",
+			relation.SourceTypeName,
+			RelationshipType(relation),
+			relation.TargetTypeName,
+		)
+		declared[pattern] = true
+		if !patterns[pattern] {
+			drift.AddWarnSchemaDrift(
+				fmt.Sprintf("relationship %s is missing from the database", pattern),
+				rend.DeclarationLocation(relation, fmt.Sprintf(
+					"relation '%s' of entity type '%s'",
+					relation.RelationName,
+					relation.DeclaringTypeName(),
+				)),
+			)
+		}
+	}
+	undeclared = undeclared[:0]
+	for pattern := range patterns {
+		if !declared[pattern] {
+			undeclared = append(undeclared, pattern)
+		}
+	}
+	sort.Strings(undeclared)
+	for _, pattern := range undeclared {
+		drift.AddWarnSchemaDrift(
+			fmt.Sprintf("relationship %s isn't declared by the schema", pattern),
+			databaseLocation,
+		)
+	}
+	return drift, nil
+}
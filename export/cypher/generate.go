@@ -0,0 +1,291 @@
+// Package cypher generates Cypher schema statements from document models
+// and detects the drift of graph database schemas
+package cypher
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/romshark/TypeBook/export"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// Options represents the options of the Cypher generator
+type Options struct {
+	// ExistenceConstraints enables property existence constraints
+	// for non-nullable properties. They require Neo4j Enterprise Edition
+	ExistenceConstraints bool
+
+	// TypeConstraints enables property type constraints for properties
+	// of scalar types mapped to Cypher types such as the prelude type
+	// "Date". They require Neo4j Enterprise Edition
+	TypeConstraints bool
+}
+
+// DefaultOptions returns the default generator options
+func DefaultOptions() Options {
+	return Options{ExistenceConstraints: true, TypeConstraints: true}
+}
+
+// plainNamePattern matches names that don't need escaping
+var plainNamePattern = regexp.MustCompile("^[A-Za-z_][A-Za-z0-9_]*$")
+
+// property returns the given property name or label escaped if necessary
+func property(name string) string {
+	if plainNamePattern.MatchString(name) {
+		return name
+	}
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
+
+// RelationshipType returns the relationship type of the given relation
+// such as "ACTED_IN" for "ActedIn"
+func RelationshipType(relation *rend.EntityRelationType) string {
+	return strings.ToUpper(export.SnakeCase(relation.TypeName.RelationType))
+}
+
+// Relations returns the distinct relations of the given document model
+// in the document order
+func Relations(doc *rend.Document) []*rend.EntityRelationType {
+	relations := make([]*rend.EntityRelationType, 0, len(doc.Relations))
+	declared := make(map[string]bool, len(doc.Relations))
+	for _, entity := range doc.OrderedEntityTypes() {
+		for _, relation := range doc.OrderedRelations(entity) {
+			name := relation.TypeName.String()
+			if !declared[name] {
+				declared[name] = true
+				relations = append(relations, relation)
+			}
+		}
+	}
+	return relations
+}
+
+// generator represents the state of a statement generation
+type generator struct {
+	doc     *rend.Document
+	options Options
+	out     bytes.Buffer
+
+	// names marks the names of the written constraints and indexes
+	names map[string]bool
+}
+
+// Generate returns Cypher statements creating the constraints
+// and indexes of the labels of the entity types and the
+// relationship types of the relations of the given validated
+// document model
+func Generate(doc *rend.Document, options Options) ([]byte, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("missing document model")
+	}
+	g := &generator{
+		doc:     doc,
+		options: options,
+		names:   make(map[string]bool),
+	}
+
+	title := strings.TrimSpace(doc.Metadata.Title)
+	if version := doc.Metadata.Version; version != "" {
+		title += " " + version
+	}
+	fmt.Fprintf(&g.out, "// %s\n// Generated by TypeBook\n\n", title)
+
+	for _, entity := range doc.OrderedEntityTypes() {
+		g.label(entity)
+	}
+	for _, relation := range Relations(doc) {
+		g.relationshipType(relation)
+	}
+	return bytes.TrimRight(g.out.Bytes(), "\n"), nil
+}
+
+// comment writes the given text as comment lines
+func (g *generator) comment(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(&g.out, "// %s\n", strings.TrimRight(line, " "))
+	}
+}
+
+// statement writes the given statement unless a statement
+// of the same name was already written
+func (g *generator) statement(name, format string, args ...interface{}) {
+	if g.names[name] {
+		return
+	}
+	g.names[name] = true
+	fmt.Fprintf(&g.out, format+";\n", args...)
+}
+
+// isIndexable returns true if the given field is stored
+// in an indexable property, otherwise returns false
+func isIndexable(field rend.TypedField) bool {
+	switch field.Type.(type) {
+	case *rend.ScalarType, *rend.EnumerationType:
+		return true
+	}
+	return false
+}
+
+// propertyType returns the Cypher property type the type of the given
+// field is mapped to and true if it's mapped, otherwise returns false
+func propertyType(field rend.TypedField) (string, bool) {
+	scalarType, isScalar := field.Type.(*rend.ScalarType)
+	if !isScalar {
+		return "", false
+	}
+	mapped, isMapped := scalarType.Mappings["cypher"]
+	if !isMapped {
+		return "", false
+	}
+	if field.IsList {
+		return "LIST<" + mapped + " NOT NULL>", true
+	}
+	return mapped, true
+}
+
+// label writes the constraints and indexes of the label
+// of the given entity type
+func (g *generator) label(entity *rend.EntityType) {
+	label := property(entity.TypeName)
+	prefix := export.SnakeCase(entity.TypeName) + "_"
+	g.comment("(:" + label + ")\n" + entity.Description)
+
+	for _, field := range g.doc.OrderedFields(entity) {
+		name := prefix + export.SnakeCase(field.Name)
+		if !isIndexable(field) {
+			fmt.Fprintf(
+				&g.out,
+				"// %s of type %s isn't indexable\n",
+				field.Name,
+				field.Type.Name(),
+			)
+			continue
+		}
+		isKey := field.Name == "id" && !field.Nullable && !field.IsList
+		if isKey {
+			// The uniqueness constraint is backed by an index
+			g.statement(
+				name+"_unique",
+				"CREATE CONSTRAINT %s IF NOT EXISTS\n"+
+					"FOR (n:%s) REQUIRE n.%s IS UNIQUE",
+				name+"_unique",
+				label,
+				property(field.Name),
+			)
+		}
+		if g.options.ExistenceConstraints && !field.Nullable {
+			g.statement(
+				name+"_exists",
+				"CREATE CONSTRAINT %s IF NOT EXISTS\n"+
+					"FOR (n:%s) REQUIRE n.%s IS NOT NULL",
+				name+"_exists",
+				label,
+				property(field.Name),
+			)
+		}
+		if t, isMapped := propertyType(field); isMapped &&
+			g.options.TypeConstraints {
+			g.statement(
+				name+"_type",
+				"CREATE CONSTRAINT %s IF NOT EXISTS\n"+
+					"FOR (n:%s) REQUIRE n.%s IS :: %s",
+				name+"_type",
+				label,
+				property(field.Name),
+				t,
+			)
+		}
+		if !isKey {
+			g.statement(
+				name,
+				"CREATE INDEX %s IF NOT EXISTS\n"+
+					"FOR (n:%s) ON (n.%s)",
+				name,
+				label,
+				property(field.Name),
+			)
+		}
+	}
+	g.out.WriteString("\n")
+}
+
+// relationshipType writes the documentation and the property
+// constraints and indexes of the relationship type of the given relation
+func (g *generator) relationshipType(relation *rend.EntityRelationType) {
+	relationshipType := RelationshipType(relation)
+	prefix := strings.ToLower(relationshipType) + "_"
+
+	fields := g.doc.OrderedFields(relation)
+	properties := make([]string, len(fields))
+	for i, field := range fields {
+		dataType := field.Type.Name()
+		if field.IsList {
+			dataType = "List<" + dataType + ">"
+		}
+		if field.Nullable {
+			dataType += "?"
+		}
+		properties[i] = field.Name + ": " + dataType
+	}
+	pattern := fmt.Sprintf(
+		"(:%s)-[:%s]->(:%s)",
+		property(relation.SourceTypeName),
+		relationshipType,
+		property(relation.TargetTypeName),
+	)
+	if len(properties) > 0 {
+		pattern = fmt.Sprintf(
+			"(:%s)-[:%s {%s}]->(:%s)",
+			property(relation.SourceTypeName),
+			relationshipType,
+			strings.Join(properties, ", "),
+			property(relation.TargetTypeName),
+		)
+	}
+	g.comment(pattern + "\n" + relation.Description)
+
+	for _, field := range fields {
+		name := prefix + export.SnakeCase(field.Name)
+		if !isIndexable(field) {
+			continue
+		}
+		if g.options.ExistenceConstraints && !field.Nullable {
+			g.statement(
+				name+"_exists",
+				"CREATE CONSTRAINT %s IF NOT EXISTS\n"+
+					"FOR ()-[r:%s]-() REQUIRE r.%s IS NOT NULL",
+				name+"_exists",
+				relationshipType,
+				property(field.Name),
+			)
+		}
+		if t, isMapped := propertyType(field); isMapped &&
+			g.options.TypeConstraints {
+			g.statement(
+				name+"_type",
+				"CREATE CONSTRAINT %s IF NOT EXISTS\n"+
+					"FOR ()-[r:%s]-() REQUIRE r.%s IS :: %s",
+				name+"_type",
+				relationshipType,
+				property(field.Name),
+				t,
+			)
+		}
+		g.statement(
+			name,
+			"CREATE INDEX %s IF NOT EXISTS\n"+
+				"FOR ()-[r:%s]-() ON (r.%s)",
+			name,
+			relationshipType,
+			property(field.Name),
+		)
+	}
+	g.out.WriteString("\n")
+}
@@ -0,0 +1,33 @@
+// Movie Theater 1.0.0
+// Generated by TypeBook
+
+// (:Actor)
+CREATE CONSTRAINT actor_id_unique IF NOT EXISTS
+FOR (n:Actor) REQUIRE n.id IS UNIQUE;
+CREATE INDEX actor_description IF NOT EXISTS
+FOR (n:Actor) ON (n.description);
+CREATE INDEX actor_first_name IF NOT EXISTS
+FOR (n:Actor) ON (n.firstName);
+CREATE INDEX actor_last_name IF NOT EXISTS
+FOR (n:Actor) ON (n.lastName);
+CREATE INDEX actor_gender IF NOT EXISTS
+FOR (n:Actor) ON (n.gender);
+CREATE INDEX actor_birthdate IF NOT EXISTS
+FOR (n:Actor) ON (n.birthdate);
+// social of type SocialLinks isn't indexable
+
+// (:Movie)
+CREATE CONSTRAINT movie_id_unique IF NOT EXISTS
+FOR (n:Movie) REQUIRE n.id IS UNIQUE;
+CREATE INDEX movie_name IF NOT EXISTS
+FOR (n:Movie) ON (n.name);
+CREATE INDEX movie_description IF NOT EXISTS
+FOR (n:Movie) ON (n.description);
+CREATE INDEX movie_genre IF NOT EXISTS
+FOR (n:Movie) ON (n.genre);
+CREATE INDEX movie_publication IF NOT EXISTS
+FOR (n:Movie) ON (n.publication);
+CREATE INDEX movie_duration IF NOT EXISTS
+FOR (n:Movie) ON (n.duration);
+
+// (:Actor)-[:ACTED_IN]->(:Movie)
@@ -0,0 +1,51 @@
+// Movie Theater 1.0.0
+// Generated by TypeBook
+
+// (:Actor)
+CREATE CONSTRAINT actor_id_unique IF NOT EXISTS
+FOR (n:Actor) REQUIRE n.id IS UNIQUE;
+CREATE CONSTRAINT actor_id_exists IF NOT EXISTS
+FOR (n:Actor) REQUIRE n.id IS NOT NULL;
+CREATE INDEX actor_description IF NOT EXISTS
+FOR (n:Actor) ON (n.description);
+CREATE CONSTRAINT actor_first_name_exists IF NOT EXISTS
+FOR (n:Actor) REQUIRE n.firstName IS NOT NULL;
+CREATE INDEX actor_first_name IF NOT EXISTS
+FOR (n:Actor) ON (n.firstName);
+CREATE CONSTRAINT actor_last_name_exists IF NOT EXISTS
+FOR (n:Actor) REQUIRE n.lastName IS NOT NULL;
+CREATE INDEX actor_last_name IF NOT EXISTS
+FOR (n:Actor) ON (n.lastName);
+CREATE CONSTRAINT actor_gender_exists IF NOT EXISTS
+FOR (n:Actor) REQUIRE n.gender IS NOT NULL;
+CREATE INDEX actor_gender IF NOT EXISTS
+FOR (n:Actor) ON (n.gender);
+CREATE INDEX actor_birthdate IF NOT EXISTS
+FOR (n:Actor) ON (n.birthdate);
+// social of type SocialLinks isn't indexable
+
+// (:Movie)
+CREATE CONSTRAINT movie_id_unique IF NOT EXISTS
+FOR (n:Movie) REQUIRE n.id IS UNIQUE;
+CREATE CONSTRAINT movie_id_exists IF NOT EXISTS
+FOR (n:Movie) REQUIRE n.id IS NOT NULL;
+CREATE CONSTRAINT movie_name_exists IF NOT EXISTS
+FOR (n:Movie) REQUIRE n.name IS NOT NULL;
+CREATE INDEX movie_name IF NOT EXISTS
+FOR (n:Movie) ON (n.name);
+CREATE INDEX movie_description IF NOT EXISTS
+FOR (n:Movie) ON (n.description);
+CREATE CONSTRAINT movie_genre_exists IF NOT EXISTS
+FOR (n:Movie) REQUIRE n.genre IS NOT NULL;
+CREATE INDEX movie_genre IF NOT EXISTS
+FOR (n:Movie) ON (n.genre);
+CREATE CONSTRAINT movie_publication_exists IF NOT EXISTS
+FOR (n:Movie) REQUIRE n.publication IS NOT NULL;
+CREATE INDEX movie_publication IF NOT EXISTS
+FOR (n:Movie) ON (n.publication);
+CREATE CONSTRAINT movie_duration_exists IF NOT EXISTS
+FOR (n:Movie) REQUIRE n.duration IS NOT NULL;
+CREATE INDEX movie_duration IF NOT EXISTS
+FOR (n:Movie) ON (n.duration);
+
+// (:Actor)-[:ACTED_IN]->(:Movie)
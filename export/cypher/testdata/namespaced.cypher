@@ -0,0 +1,18 @@
+// Store
+// Generated by TypeBook
+
+// (:Customer)
+CREATE CONSTRAINT customer_name_exists IF NOT EXISTS
+FOR (n:Customer) REQUIRE n.name IS NOT NULL;
+CREATE INDEX customer_name IF NOT EXISTS
+FOR (n:Customer) ON (n.name);
+
+// (:`billing.Invoice`)
+// total of type billing.Money isn't indexable
+// items of type billing.Money isn't indexable
+CREATE INDEX billing_invoice_note IF NOT EXISTS
+FOR (n:`billing.Invoice`) ON (n.note);
+
+// (:Customer)-[:BILLED]->(:`billing.Invoice`)
+
+// (:`billing.Invoice`)-[:PAID_BY]->(:Customer)
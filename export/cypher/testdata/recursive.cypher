@@ -0,0 +1,11 @@
+// Family
+// Generated by TypeBook
+
+// (:Person)
+CREATE CONSTRAINT person_name_exists IF NOT EXISTS
+FOR (n:Person) REQUIRE n.name IS NOT NULL;
+CREATE INDEX person_name IF NOT EXISTS
+FOR (n:Person) ON (n.name);
+// ancestry of type Node isn't indexable
+
+// (:Person)-[:CHILD_OF]->(:Person)
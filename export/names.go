@@ -0,0 +1,39 @@
+// Package export provides the declarations shared by the exporters
+// generating schemas of other schema languages from document models
+package export
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SnakeCase converts the given name to snake case
+// such as "firstName" and "ActedIn" to "first_name" and "acted_in"
+func SnakeCase(name string) string {
+	runes := []rune(name)
+	var builder strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			builder.WriteByte('_')
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 && runes[i-1] != '_' {
+			// Separate words at lower-to-upper transitions
+			// and before the last capital of an acronym
+			if unicode.IsLower(runes[i-1]) ||
+				unicode.IsDigit(runes[i-1]) ||
+				i+1 < len(runes) && unicode.IsLower(runes[i+1]) &&
+					unicode.IsUpper(runes[i-1]) {
+				builder.WriteByte('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+	return builder.String()
+}
+
+// Namespace returns the namespace derived from the given
+// document title such as "movie_theater" for "Movie Theater"
+func Namespace(title string) string {
+	return strings.Trim(SnakeCase(strings.TrimSpace(title)), "_")
+}
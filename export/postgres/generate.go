@@ -0,0 +1,466 @@
+// Package postgres generates PostgreSQL schemas from document models
+package postgres
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/export"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// column represents a column of a generated table
+type column struct {
+	// Name is the unquoted column name
+	Name        string
+	Type        string
+	NotNull     bool
+	Description string
+
+	// References is the referenced table and column
+	// such as "actor (id)" or empty if the column is no foreign key
+	References string
+}
+
+// table represents a generated table
+type table struct {
+	// Name is the quoted table name
+	Name        string
+	Description string
+	Columns     []column
+	PrimaryKey  []string
+
+	// KeyType is the type of the primary key column
+	// foreign keys referencing the table are declared with
+	KeyType string
+}
+
+// reference returns the primary key column of the table
+// referenced by foreign keys such as "actor (id)"
+func (t *table) reference() string {
+	return t.Name + " (" + identifier(t.PrimaryKey[0]) + ")"
+}
+
+// hasColumn returns true if the table has a column of the given name,
+// otherwise returns false
+func (t *table) hasColumn(name string) bool {
+	for _, col := range t.Columns {
+		if col.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// generator represents the state of a schema generation
+type generator struct {
+	doc         *rend.Document
+	options     Options
+	out         bytes.Buffer
+	tables      map[string]*table
+	entities    []*table
+	joinTables  []*table
+	foreignKeys []string
+	comments    []string
+}
+
+// Generate returns PostgreSQL DDL statements declaring enumeration types
+// for the enumeration types, domains for the constrained scalar types
+// and tables for the entity types and relations of the given
+// validated document model
+func Generate(doc *rend.Document, options Options) ([]byte, error) {
+	if doc == nil {
+		return nil, fmt.Errorf("missing document model")
+	}
+	scalarNames := make([]string, 0, len(options.ScalarTypes))
+	for name := range options.ScalarTypes {
+		scalarNames = append(scalarNames, name)
+	}
+	sort.Strings(scalarNames)
+	for _, name := range scalarNames {
+		if _, isScalar := doc.ScalarTypes[name]; !isScalar {
+			return nil, fmt.Errorf(
+				"scalar type map references undefined scalar type '%s'",
+				name,
+			)
+		}
+	}
+
+	g := &generator{
+		doc:     doc,
+		options: options,
+		tables:  make(map[string]*table),
+	}
+
+	// Declare the entity tables before their relations
+	// to determine the types of the foreign keys
+	for _, entity := range doc.OrderedEntityTypes() {
+		g.entityTable(entity)
+	}
+	declaredRelations := make(map[string]bool)
+	for _, entity := range doc.OrderedEntityTypes() {
+		for _, relation := range doc.OrderedRelations(entity) {
+			name := relation.TypeName.String()
+			if declaredRelations[name] {
+				continue
+			}
+			declaredRelations[name] = true
+			g.relation(relation)
+		}
+	}
+
+	g.header()
+	for _, t := range doc.OrderedEnumerationTypes() {
+		g.enumeration(t)
+	}
+	for _, t := range doc.OrderedScalarTypes() {
+		g.domain(t)
+	}
+	for _, t := range g.entities {
+		g.table(t)
+	}
+	for _, t := range g.joinTables {
+		g.table(t)
+	}
+	for _, statement := range g.foreignKeys {
+		g.out.WriteString(statement + "\n\n")
+	}
+	for _, statement := range g.comments {
+		g.out.WriteString(statement + "\n")
+	}
+	return bytes.TrimRight(g.out.Bytes(), "\n"), nil
+}
+
+// header writes the comment identifying the source document
+func (g *generator) header() {
+	title := strings.TrimSpace(g.doc.Metadata.Title)
+	if version := g.doc.Metadata.Version; version != "" {
+		title += " " + version
+	}
+	fmt.Fprintf(&g.out, "-- %s\n-- Generated by TypeBook\n\n", title)
+}
+
+// comment records a COMMENT ON statement if the description isn't empty
+func (g *generator) comment(object, name, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	g.comments = append(g.comments, fmt.Sprintf(
+		"COMMENT ON %s %s IS %s;",
+		object,
+		name,
+		literal(description),
+	))
+}
+
+// enumeration writes the enumeration type of the given enumeration
+func (g *generator) enumeration(t *rend.EnumerationType) {
+	name := identifier(export.SnakeCase(t.TypeName))
+	values := g.doc.OrderedEnumerationValues(t)
+	labels := make([]string, len(values))
+	for i, value := range values {
+		labels[i] = "\t" + literal(value.Name)
+	}
+	fmt.Fprintf(
+		&g.out,
+		"CREATE TYPE %s AS ENUM (\n%s\n);\n\n",
+		name,
+		strings.Join(labels, ",\n"),
+	)
+	g.comment("TYPE", name, t.Description)
+}
+
+// domainChecks returns the check expressions of the domain
+// of the given scalar type or nil if it's unconstrained
+func domainChecks(t *rend.ScalarType) []string {
+	checks := make([]string, 0)
+	c := t.Constraints
+	switch t.Kind {
+	case document.NumberKind, document.IntegerKind:
+		if c.Minimum != nil {
+			checks = append(checks, "VALUE >= "+
+				strconv.FormatFloat(*c.Minimum, 'g', -1, 64))
+		}
+		if c.Maximum != nil {
+			checks = append(checks, "VALUE <= "+
+				strconv.FormatFloat(*c.Maximum, 'g', -1, 64))
+		}
+	case document.StringKind, document.AnyKind:
+		if c.MinLength != nil {
+			checks = append(checks, fmt.Sprintf(
+				"char_length(VALUE) >= %d",
+				*c.MinLength,
+			))
+		}
+		if c.MaxLength != nil {
+			checks = append(checks, fmt.Sprintf(
+				"char_length(VALUE) <= %d",
+				*c.MaxLength,
+			))
+		}
+		if c.Pattern != nil {
+			checks = append(checks, "VALUE ~ "+literal(c.Pattern.String()))
+		}
+	}
+	if len(checks) < 1 {
+		return nil
+	}
+	return checks
+}
+
+// mappedType returns the column type the given scalar type is mapped to
+// by the options or by default and false if it isn't mapped
+func (g *generator) mappedType(t *rend.ScalarType) (string, bool) {
+	if columnType, isMapped := g.options.ScalarTypes[t.TypeName]; isMapped {
+		return columnType, true
+	}
+	columnType, isMapped := t.Mappings["postgres"]
+	return columnType, isMapped
+}
+
+// baseType returns the column type of the given scalar type
+// disregarding its constraints
+func (g *generator) baseType(t *rend.ScalarType) string {
+	if columnType, isMapped := g.mappedType(t); isMapped {
+		return columnType
+	}
+	return kindTypes[t.Kind]
+}
+
+// isDomain returns true if the given scalar type is stored in a domain
+// because it's constrained and its column type isn't mapped,
+// otherwise returns false
+func (g *generator) isDomain(t *rend.ScalarType) bool {
+	_, isMapped := g.mappedType(t)
+	return !isMapped && domainChecks(t) != nil
+}
+
+// domain writes the domain of the given scalar type if it's stored in one
+func (g *generator) domain(t *rend.ScalarType) {
+	if !g.isDomain(t) {
+		return
+	}
+	checks := domainChecks(t)
+	name := identifier(export.SnakeCase(t.TypeName))
+	fmt.Fprintf(&g.out, "CREATE DOMAIN %s AS %s", name, g.baseType(t))
+	for _, check := range checks {
+		fmt.Fprintf(&g.out, "\n\tCHECK (%s)", check)
+	}
+	g.out.WriteString(";\n\n")
+	g.comment("DOMAIN", name, t.Description)
+}
+
+// columnType returns the column type of the given field
+func (g *generator) columnType(field rend.TypedField) string {
+	var columnType string
+	switch t := field.Type.(type) {
+	case *rend.ScalarType:
+		columnType = g.baseType(t)
+		if g.isDomain(t) {
+			columnType = identifier(export.SnakeCase(t.TypeName))
+		}
+	case *rend.EnumerationType:
+		columnType = identifier(export.SnakeCase(t.TypeName))
+	default:
+		// Lists of composites are stored in a single JSONB array
+		return "jsonb"
+	}
+	if field.IsList {
+		columnType += "[]"
+	}
+	return columnType
+}
+
+// fieldColumns returns the columns storing the given field.
+// Composite-typed fields are flattened into the columns of their fields
+// unless they're lists, recursive or stored in JSONB columns
+func (g *generator) fieldColumns(
+	name string,
+	field rend.TypedField,
+	nullable bool,
+	flattening map[string]bool,
+) []column {
+	nullable = nullable || field.Nullable
+	composite, isComposite := field.Type.(*rend.CompositeType)
+	if !isComposite ||
+		field.IsList ||
+		g.options.Composites != FlattenedComposites ||
+		flattening[composite.TypeName] {
+		return []column{{
+			Name:        name,
+			Type:        g.columnType(field),
+			NotNull:     !nullable,
+			Description: field.Description,
+		}}
+	}
+
+	flattening[composite.TypeName] = true
+	defer delete(flattening, composite.TypeName)
+	columns := make([]column, 0, len(composite.Metadata))
+	for _, nested := range g.doc.OrderedFields(composite) {
+		columns = append(columns, g.fieldColumns(
+			name+"_"+export.SnakeCase(nested.Name),
+			nested,
+			nullable,
+			flattening,
+		)...)
+	}
+	return columns
+}
+
+// metadataColumns returns the columns storing the metadata
+// of the given type
+func (g *generator) metadataColumns(t rend.ComplexType) []column {
+	columns := make([]column, 0, t.TotalMetadataFields())
+	for _, field := range g.doc.OrderedFields(t) {
+		columns = append(columns, g.fieldColumns(
+			export.SnakeCase(field.Name),
+			field,
+			false,
+			make(map[string]bool),
+		)...)
+	}
+	return columns
+}
+
+// entityTable declares the table of the given entity type.
+// A non-nullable scalar or enumeration field named "id" becomes
+// the primary key, otherwise an identity column is added
+func (g *generator) entityTable(entity *rend.EntityType) {
+	t := &table{
+		Name:        identifier(export.SnakeCase(entity.TypeName)),
+		Description: entity.Description,
+		Columns:     g.metadataColumns(entity),
+	}
+
+	if id, hasID := entity.Metadata["id"]; hasID && !id.Nullable && !id.IsList {
+		switch id.Type.(type) {
+		case *rend.ScalarType, *rend.EnumerationType:
+			t.PrimaryKey = []string{"id"}
+			t.KeyType = g.columnType(id)
+		}
+	}
+	if t.PrimaryKey == nil {
+		key := "id"
+		if t.hasColumn(key) {
+			key = "row_id"
+		}
+		t.PrimaryKey = []string{key}
+		t.KeyType = "bigint"
+		t.Columns = append([]column{{
+			Name:    key,
+			Type:    "bigint GENERATED ALWAYS AS IDENTITY",
+			NotNull: true,
+		}}, t.Columns...)
+	}
+
+	g.tables[entity.TypeName] = t
+	g.entities = append(g.entities, t)
+}
+
+// relation declares the foreign key or join table storing
+// the given relation
+func (g *generator) relation(relation *rend.EntityRelationType) {
+	source := g.tables[relation.SourceTypeName]
+	target := g.tables[relation.TargetTypeName]
+	if source == nil || target == nil {
+		return
+	}
+	relationType := export.SnakeCase(relation.TypeName.RelationType)
+
+	// Store relations without metadata in a foreign key column
+	// unless the column name is taken
+	if g.options.Relations == ForeignKeyRelations &&
+		len(relation.Metadata) < 1 {
+		name := relationType + "_id"
+		if !source.hasColumn(name) {
+			source.Columns = append(source.Columns, column{
+				Name:        name,
+				Type:        target.KeyType,
+				Description: relation.Description,
+			})
+			g.foreignKeys = append(g.foreignKeys, fmt.Sprintf(
+				"ALTER TABLE %s\n\tADD FOREIGN KEY (%s) REFERENCES %s (%s);",
+				source.Name,
+				identifier(name),
+				target.Name,
+				identifier(target.PrimaryKey[0]),
+			))
+			return
+		}
+	}
+
+	sourceColumn := export.SnakeCase(relation.SourceTypeName) + "_id"
+	targetColumn := export.SnakeCase(relation.TargetTypeName) + "_id"
+	if sourceColumn == targetColumn {
+		sourceColumn, targetColumn = "source_id", "target_id"
+	}
+	join := &table{
+		Name: identifier(
+			export.SnakeCase(relation.SourceTypeName) + "_" +
+				relationType + "_" +
+				export.SnakeCase(relation.TargetTypeName),
+		),
+		Description: relation.Description,
+		Columns: append([]column{
+			{
+				Name:       sourceColumn,
+				Type:       source.KeyType,
+				NotNull:    true,
+				References: source.reference(),
+			},
+			{
+				Name:       targetColumn,
+				Type:       target.KeyType,
+				NotNull:    true,
+				References: target.reference(),
+			},
+		}, g.metadataColumns(relation)...),
+		PrimaryKey: []string{sourceColumn, targetColumn},
+	}
+	g.joinTables = append(g.joinTables, join)
+}
+
+// table writes the CREATE TABLE statement of the given table
+func (g *generator) table(t *table) {
+	g.comment("TABLE", t.Name, t.Description)
+	definitions := make([]string, 0, len(t.Columns)+1)
+	for _, col := range t.Columns {
+		definition := "\t" + identifier(col.Name) + " " + col.Type
+		if len(t.PrimaryKey) == 1 && t.PrimaryKey[0] == col.Name {
+			definition += " PRIMARY KEY"
+		} else if col.NotNull {
+			definition += " NOT NULL"
+		}
+		if col.References != "" {
+			definition += " REFERENCES " + col.References
+		}
+		definitions = append(definitions, definition)
+		g.comment(
+			"COLUMN",
+			t.Name+"."+identifier(col.Name),
+			col.Description,
+		)
+	}
+	if len(t.PrimaryKey) > 1 {
+		keys := make([]string, len(t.PrimaryKey))
+		for i, key := range t.PrimaryKey {
+			keys[i] = identifier(key)
+		}
+		definitions = append(definitions, fmt.Sprintf(
+			"\tPRIMARY KEY (%s)",
+			strings.Join(keys, ", "),
+		))
+	}
+	fmt.Fprintf(
+		&g.out,
+		"CREATE TABLE %s (\n%s\n);\n\n",
+		t.Name,
+		strings.Join(definitions, ",\n"),
+	)
+}
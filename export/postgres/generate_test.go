@@ -0,0 +1,99 @@
+package postgres
+
+import (
+	"flag"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// update overwrites the golden files with the generated schemas
+var update = flag.Bool("update", false, "update the golden files")
+
+// loadModel returns the document model of the given schema file
+func loadModel(t *testing.T, path string) *rend.Document {
+	t.Helper()
+	source, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatalf("couldn't read schema: %s", err)
+	}
+	doc, _, err := document.New(source)
+	if err != nil {
+		t.Fatalf("couldn't parse schema: %s", err)
+	}
+	model, errs, _, err := rend.NewModel(doc, time.Time{})
+	if err != nil || errs.HasErrors() {
+		t.Fatalf("couldn't initialize model: %s %#v", err, errs)
+	}
+	return model
+}
+
+// TestGenerate verifies the generated schemas
+// against the golden files in testdata
+func TestGenerate(t *testing.T) {
+	flattened := DefaultOptions()
+	flattened.Composites = FlattenedComposites
+	flattened.Relations = ForeignKeyRelations
+
+	for _, tt := range []struct {
+		name    string
+		path    string
+		options Options
+	}{
+		{
+			name:    "example",
+			path:    "../../example.yml",
+			options: DefaultOptions(),
+		},
+		{
+			name:    "example-flattened",
+			path:    "../../example.yml",
+			options: flattened,
+		},
+		{
+			name:    "namespaced",
+			path:    "../testdata/namespaced.yml",
+			options: DefaultOptions(),
+		},
+		{
+			name:    "recursive",
+			path:    "../testdata/recursive.yml",
+			options: DefaultOptions(),
+		},
+		{
+			name:    "recursive-flattened",
+			path:    "../testdata/recursive.yml",
+			options: flattened,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, err := Generate(loadModel(t, tt.path), tt.options)
+			if err != nil {
+				t.Fatalf("couldn't generate: %s", err)
+			}
+			schema = append(schema, '\n')
+
+			golden := filepath.Join("testdata", tt.name+".sql")
+			if *update {
+				if err := ioutil.WriteFile(golden, schema, 0644); err != nil {
+					t.Fatalf("couldn't update golden file: %s", err)
+				}
+			}
+			expected, err := ioutil.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("couldn't read golden file: %s", err)
+			}
+			if string(schema) != string(expected) {
+				t.Fatalf(
+					"unexpected schema, expected:\n%s\ngot:\n%s",
+					expected,
+					schema,
+				)
+			}
+		})
+	}
+}
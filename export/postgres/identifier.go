@@ -0,0 +1,47 @@
+package postgres
+
+import (
+	"regexp"
+	"strings"
+)
+
+// plainIdentifierPattern matches identifiers that don't need quoting
+var plainIdentifierPattern = regexp.MustCompile("^[a-z_][a-z0-9_]*$")
+
+// reservedWords lists the reserved key words that can't be used
+// as unquoted identifiers
+var reservedWords = map[string]bool{
+	"all": true, "analyse": true, "analyze": true, "and": true,
+	"any": true, "array": true, "as": true, "asc": true,
+	"asymmetric": true, "both": true, "case": true, "cast": true,
+	"check": true, "collate": true, "column": true, "constraint": true,
+	"create": true, "current_date": true, "current_role": true,
+	"current_time": true, "current_timestamp": true, "current_user": true,
+	"default": true, "deferrable": true, "desc": true, "distinct": true,
+	"do": true, "else": true, "end": true, "except": true, "false": true,
+	"fetch": true, "for": true, "foreign": true, "from": true,
+	"grant": true, "group": true, "having": true, "in": true,
+	"initially": true, "intersect": true, "into": true, "lateral": true,
+	"leading": true, "limit": true, "localtime": true,
+	"localtimestamp": true, "not": true, "null": true, "offset": true,
+	"on": true, "only": true, "or": true, "order": true, "placing": true,
+	"primary": true, "references": true, "returning": true,
+	"select": true, "session_user": true, "some": true,
+	"symmetric": true, "table": true, "then": true, "to": true,
+	"trailing": true, "true": true, "union": true, "unique": true,
+	"user": true, "using": true, "variadic": true, "when": true,
+	"where": true, "window": true, "with": true,
+}
+
+// identifier returns the given identifier quoted if necessary
+func identifier(name string) string {
+	if plainIdentifierPattern.MatchString(name) && !reservedWords[name] {
+		return name
+	}
+	return `"` + strings.Replace(name, `"`, `""`, -1) + `"`
+}
+
+// literal returns the given text as a string literal
+func literal(text string) string {
+	return "'" + strings.Replace(text, "'", "''", -1) + "'"
+}
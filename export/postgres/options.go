@@ -0,0 +1,111 @@
+package postgres
+
+import (
+	"fmt"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// CompositeMode defines how composite-typed fields are stored
+type CompositeMode uint8
+
+const (
+	// JSONBComposites stores composite-typed fields in JSONB columns
+	JSONBComposites CompositeMode = iota
+
+	// FlattenedComposites stores each field of a composite-typed field
+	// in a column of its own prefixed by the name of the field.
+	// Lists of composites are stored in JSONB columns
+	FlattenedComposites
+)
+
+// String stringifies the value
+func (m CompositeMode) String() string {
+	switch m {
+	case JSONBComposites:
+		return "jsonb"
+	case FlattenedComposites:
+		return "flatten"
+	}
+	panic(fmt.Errorf("couldn't stringify invalid CompositeMode value: %d", m))
+}
+
+// FromString initializes the value from a string
+func (m *CompositeMode) FromString(str string) error {
+	switch str {
+	case "jsonb":
+		*m = JSONBComposites
+		return nil
+	case "flatten":
+		*m = FlattenedComposites
+		return nil
+	}
+	return fmt.Errorf("invalid composite mode: '%s'", str)
+}
+
+// RelationMode defines how relations between entities are stored
+type RelationMode uint8
+
+const (
+	// JoinTableRelations stores all relations in join tables
+	JoinTableRelations RelationMode = iota
+
+	// ForeignKeyRelations stores relations without metadata in foreign key
+	// columns of the source table. Relations with metadata are
+	// stored in join tables carrying the metadata as columns
+	ForeignKeyRelations
+)
+
+// String stringifies the value
+func (m RelationMode) String() string {
+	switch m {
+	case JoinTableRelations:
+		return "join-tables"
+	case ForeignKeyRelations:
+		return "foreign-keys"
+	}
+	panic(fmt.Errorf("couldn't stringify invalid RelationMode value: %d", m))
+}
+
+// FromString initializes the value from a string
+func (m *RelationMode) FromString(str string) error {
+	switch str {
+	case "join-tables":
+		*m = JoinTableRelations
+		return nil
+	case "foreign-keys":
+		*m = ForeignKeyRelations
+		return nil
+	}
+	return fmt.Errorf("invalid relation mode: '%s'", str)
+}
+
+// kindTypes maps scalar kinds to the column types
+// scalar types of the kind are stored in by default
+var kindTypes = map[document.ScalarKind]string{
+	document.AnyKind:     "text",
+	document.StringKind:  "text",
+	document.NumberKind:  "double precision",
+	document.IntegerKind: "bigint",
+	document.BooleanKind: "boolean",
+	document.TimeKind:    "timestamptz",
+}
+
+// Options represents the options of the PostgreSQL schema generator
+type Options struct {
+	Composites CompositeMode
+	Relations  RelationMode
+
+	// ScalarTypes maps scalar type names to column types overriding
+	// the column types and domains derived from the scalar type
+	ScalarTypes map[string]string
+}
+
+// DefaultOptions returns the default generator options
+func DefaultOptions() Options {
+	return Options{
+		Composites:  JSONBComposites,
+		Relations:   JoinTableRelations,
+		ScalarTypes: make(map[string]string),
+	}
+}
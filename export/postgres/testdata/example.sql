@@ -0,0 +1,65 @@
+-- Movie Theater 1.0.0
+-- Generated by TypeBook
+
+CREATE TYPE gender AS ENUM (
+	'Male',
+	'Female'
+);
+
+CREATE TYPE genre AS ENUM (
+	'Action',
+	'Adventure',
+	'Comedy',
+	'Crime',
+	'Drama',
+	'Fantasy',
+	'Historical',
+	'Horror',
+	'Mystery',
+	'Philosophical',
+	'Political',
+	'Romance',
+	'Saga',
+	'Satire',
+	'Science fiction',
+	'Thriller',
+	'Western'
+);
+
+CREATE DOMAIN duration AS double precision
+	CHECK (VALUE >= 0);
+
+CREATE DOMAIN identifier AS text
+	CHECK (char_length(VALUE) >= 1);
+
+CREATE DOMAIN email_address AS text
+	CHECK (VALUE ~ '^.+@.+\..+$');
+
+CREATE TABLE actor (
+	id identifier PRIMARY KEY,
+	description text,
+	first_name text NOT NULL,
+	last_name text NOT NULL,
+	gender gender NOT NULL,
+	birthdate timestamptz,
+	social jsonb NOT NULL
+);
+
+CREATE TABLE movie (
+	id identifier PRIMARY KEY,
+	name text[] NOT NULL,
+	description text,
+	genre genre[] NOT NULL,
+	publication timestamptz NOT NULL,
+	duration duration NOT NULL
+);
+
+CREATE TABLE actor_acted_in_movie (
+	actor_id identifier NOT NULL REFERENCES actor (id),
+	movie_id identifier NOT NULL REFERENCES movie (id),
+	PRIMARY KEY (actor_id, movie_id)
+);
+
+COMMENT ON TYPE gender IS 'Represents a gender enumeration type';
+COMMENT ON DOMAIN duration IS 'Represents a time span in seconds';
+COMMENT ON DOMAIN email_address IS 'Represents an email address according to the `^.+@.+\..+$` pattern.';
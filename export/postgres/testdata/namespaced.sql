@@ -0,0 +1,31 @@
+-- Store
+-- Generated by TypeBook
+
+CREATE TYPE billing_currency AS ENUM (
+	'Euro',
+	'US Dollar'
+);
+
+CREATE TABLE customer (
+	id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	name text NOT NULL
+);
+
+CREATE TABLE billing_invoice (
+	id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	total jsonb NOT NULL,
+	items jsonb NOT NULL,
+	note text
+);
+
+CREATE TABLE customer_billed_billing_invoice (
+	customer_id bigint NOT NULL REFERENCES customer (id),
+	billing_invoice_id bigint NOT NULL REFERENCES billing_invoice (id),
+	PRIMARY KEY (customer_id, billing_invoice_id)
+);
+
+CREATE TABLE billing_invoice_paid_by_customer (
+	billing_invoice_id bigint NOT NULL REFERENCES billing_invoice (id),
+	customer_id bigint NOT NULL REFERENCES customer (id),
+	PRIMARY KEY (billing_invoice_id, customer_id)
+);
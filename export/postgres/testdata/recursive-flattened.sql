@@ -0,0 +1,14 @@
+-- Family
+-- Generated by TypeBook
+
+CREATE TABLE person (
+	id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	name text NOT NULL,
+	ancestry_label text NOT NULL,
+	ancestry_parent jsonb,
+	ancestry_children jsonb NOT NULL,
+	child_of_id bigint
+);
+
+ALTER TABLE person
+	ADD FOREIGN KEY (child_of_id) REFERENCES person (id);
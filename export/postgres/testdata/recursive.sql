@@ -0,0 +1,14 @@
+-- Family
+-- Generated by TypeBook
+
+CREATE TABLE person (
+	id bigint GENERATED ALWAYS AS IDENTITY PRIMARY KEY,
+	name text NOT NULL,
+	ancestry jsonb NOT NULL
+);
+
+CREATE TABLE person_child_of_person (
+	source_id bigint NOT NULL REFERENCES person (id),
+	target_id bigint NOT NULL REFERENCES person (id),
+	PRIMARY KEY (source_id, target_id)
+);
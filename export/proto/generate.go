@@ -0,0 +1,337 @@
+// Package proto generates proto3 schemas from document models
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/export"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// kindTypes maps scalar kinds to the field types
+// scalar types of the kind are declared with
+var kindTypes = map[document.ScalarKind]string{
+	document.AnyKind:     "google.protobuf.Value",
+	document.StringKind:  "string",
+	document.NumberKind:  "double",
+	document.IntegerKind: "int64",
+	document.BooleanKind: "bool",
+	document.TimeKind:    "google.protobuf.Timestamp",
+}
+
+// wrapperTypes maps the field types of scalar kinds
+// to the well-known types wrapping them
+var wrapperTypes = map[string]string{
+	"string": "google.protobuf.StringValue",
+	"double": "google.protobuf.DoubleValue",
+	"int64":  "google.protobuf.Int64Value",
+	"bool":   "google.protobuf.BoolValue",
+}
+
+// imports maps well-known types to the files declaring them
+var imports = map[string]string{
+	"google.protobuf.Value":       "google/protobuf/struct.proto",
+	"google.protobuf.Timestamp":   "google/protobuf/timestamp.proto",
+	"google.protobuf.StringValue": "google/protobuf/wrappers.proto",
+	"google.protobuf.DoubleValue": "google/protobuf/wrappers.proto",
+	"google.protobuf.Int64Value":  "google/protobuf/wrappers.proto",
+	"google.protobuf.BoolValue":   "google/protobuf/wrappers.proto",
+	"google.type.Date":            "google/type/date.proto",
+}
+
+// packageNamePattern matches valid package names
+var packageNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+
+// generator represents the state of a schema generation
+type generator struct {
+	doc      *rend.Document
+	options  Options
+	previous *Lock
+	lock     *Lock
+	problems []string
+	imports  map[string]bool
+	body     bytes.Buffer
+}
+
+// Generate returns a proto3 schema declaring enums for the enumeration
+// types and messages for the composite and entity types of the given
+// validated document model. The field and value numbers of the given
+// lock are kept and the updated lock is returned.
+// Returns an error if a number is reused or changed
+func Generate(doc *rend.Document, options Options, lock *Lock) (
+	schema []byte,
+	updated *Lock,
+	err error,
+) {
+	if doc == nil {
+		return nil, nil, fmt.Errorf("missing document model")
+	}
+	if lock == nil {
+		lock = NewLock()
+	}
+	packageName := options.Package
+	if packageName == "" {
+		packageName = export.Namespace(doc.Metadata.Title)
+	}
+	if !packageNamePattern.MatchString(packageName) {
+		return nil, nil, fmt.Errorf("invalid package name: '%s'", packageName)
+	}
+
+	g := &generator{
+		doc:      doc,
+		options:  options,
+		previous: lock,
+		lock:     NewLock(),
+		imports:  make(map[string]bool),
+	}
+	for _, t := range doc.OrderedEnumerationTypes() {
+		g.enum(t)
+	}
+	for _, t := range doc.OrderedCompositeTypes() {
+		g.message(t, t.Description, t.Deprecated)
+	}
+	for _, t := range doc.OrderedEntityTypes() {
+		g.message(t, t.Description, t.Deprecated)
+	}
+
+	g.problems = append(g.problems, g.lock.Verify()...)
+	if len(g.problems) > 0 {
+		return nil, nil, fmt.Errorf(
+			"incompatible numbering:\n%s",
+			strings.Join(g.problems, "\n"),
+		)
+	}
+
+	var out bytes.Buffer
+	title := strings.TrimSpace(doc.Metadata.Title)
+	if version := doc.Metadata.Version; version != "" {
+		title += " " + version
+	}
+	fmt.Fprintf(&out, "// %s\n// Generated by TypeBook\n\n", title)
+	out.WriteString("syntax = \"proto3\";\n\n")
+	fmt.Fprintf(&out, "package %s;\n\n", packageName)
+	files := make([]string, 0, len(g.imports))
+	for file := range g.imports {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		fmt.Fprintf(&out, "import \"%s\";\n", file)
+	}
+	if len(files) > 0 {
+		out.WriteString("\n")
+	}
+	out.Write(g.body.Bytes())
+	return bytes.TrimRight(out.Bytes(), "\n"), g.lock, nil
+}
+
+// writeComment writes the given description as comment lines
+func (g *generator) writeComment(indent, description string) {
+	description = strings.TrimSpace(description)
+	if description == "" {
+		return
+	}
+	for _, line := range strings.Split(description, "\n") {
+		fmt.Fprintf(&g.body, "%s// %s\n", indent, strings.TrimRight(line, " "))
+	}
+}
+
+// writeReserved writes the reserved statements of the given numbering
+func (g *generator) writeReserved(
+	numbering numbering,
+	name func(string) string,
+) {
+	if len(numbering.Reserved) > 0 {
+		numbers := make([]string, len(numbering.Reserved))
+		for i, number := range numbering.Reserved {
+			numbers[i] = strconv.Itoa(int(number))
+		}
+		fmt.Fprintf(&g.body, "  reserved %s;\n", strings.Join(numbers, ", "))
+	}
+	if len(numbering.ReservedNames) > 0 {
+		names := make([]string, len(numbering.ReservedNames))
+		for i, reservedName := range numbering.ReservedNames {
+			names[i] = strconv.Quote(name(reservedName))
+		}
+		fmt.Fprintf(&g.body, "  reserved %s;\n", strings.Join(names, ", "))
+	}
+}
+
+// typeName returns the message or enum name of the given type name
+// joining namespaces such as "BillingInvoice" for "billing.Invoice"
+func typeName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = strings.ToUpper(part[:1]) + part[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// valueName returns the name of the given value of the given enum
+// such as "GENRE_SCIENCE_FICTION" for "Science fiction"
+func valueName(enumName, name string) string {
+	return strings.ToUpper(
+		export.SnakeCase(enumName) + "_" + export.SnakeCase(name),
+	)
+}
+
+// enum writes the enum of the given enumeration type.
+// The numbers are taken from the values if they're all integers,
+// otherwise they're assigned by the lock
+func (g *generator) enum(t *rend.EnumerationType) {
+	values := g.doc.OrderedEnumerationValues(t)
+	names := make([]string, len(values))
+	fixed := make(map[string]int32, len(values))
+	for i, value := range values {
+		names[i] = value.Name
+		number, err := strconv.ParseInt(value.Value, 10, 32)
+		if value.Kind != document.IntegerValue || err != nil {
+			fixed = nil
+		} else if fixed != nil {
+			fixed[value.Name] = int32(number)
+		}
+	}
+
+	locked := numbering{}
+	if previous := g.previous.Enums[t.TypeName]; previous != nil {
+		locked = numbering{
+			Numbers:       previous.Values,
+			Reserved:      previous.Reserved,
+			ReservedNames: previous.ReservedNames,
+		}
+	}
+	numbering, problems := renumber(t.TypeName, names, locked, fixed)
+	g.problems = append(g.problems, problems...)
+	g.lock.Enums[t.TypeName] = &EnumLock{
+		Values:        numbering.Numbers,
+		Reserved:      numbering.Reserved,
+		ReservedNames: numbering.ReservedNames,
+	}
+
+	// The first value of a proto3 enum must be zero
+	sort.SliceStable(values, func(i, j int) bool {
+		return numbering.Numbers[values[i].Name] == 0 &&
+			numbering.Numbers[values[j].Name] != 0
+	})
+
+	g.writeComment("", t.Description)
+	fmt.Fprintf(&g.body, "enum %s {\n", typeName(t.TypeName))
+	if t.Deprecated != nil {
+		g.body.WriteString("  option deprecated = true;\n")
+	}
+	g.writeReserved(numbering, func(name string) string {
+		return valueName(t.TypeName, name)
+	})
+	if len(values) < 1 || numbering.Numbers[values[0].Name] != 0 {
+		fmt.Fprintf(&g.body, "  %s = 0;\n", valueName(t.TypeName, "Unspecified"))
+	}
+	for _, value := range values {
+		options := ""
+		if value.Deprecated != nil {
+			options = " [deprecated = true]"
+		}
+		fmt.Fprintf(
+			&g.body,
+			"  %s = %d%s;\n",
+			valueName(t.TypeName, value.Name),
+			numbering.Numbers[value.Name],
+			options,
+		)
+	}
+	g.body.WriteString("}\n\n")
+}
+
+// fieldType returns the type of the given field
+// and whether it's declared optional
+func (g *generator) fieldType(field rend.TypedField) (string, bool) {
+	isOptional := field.Nullable && !field.IsList
+	var fieldType string
+	switch t := field.Type.(type) {
+	case *rend.ScalarType:
+		fieldType = kindTypes[t.Kind]
+		if mapped, isMapped := t.Mappings["proto"]; isMapped {
+			fieldType = mapped
+		}
+		if wrapper, hasWrapper := wrapperTypes[fieldType]; hasWrapper &&
+			isOptional &&
+			g.options.Nullable == WrapperTypes {
+			fieldType = wrapper
+		}
+	case *rend.EnumerationType:
+		fieldType = typeName(t.TypeName)
+	default:
+		// Message fields track presence without being optional
+		return typeName(field.Type.Name()), false
+	}
+	if file, isWellKnown := imports[fieldType]; isWellKnown {
+		g.imports[file] = true
+		return fieldType, false
+	}
+	return fieldType, isOptional
+}
+
+// message writes the message of the given composite or entity type
+func (g *generator) message(
+	t rend.ComplexType,
+	description string,
+	deprecated *rend.Deprecation,
+) {
+	fields := g.doc.OrderedFields(t)
+	names := make([]string, len(fields))
+	for i, field := range fields {
+		names[i] = field.Name
+	}
+
+	locked := numbering{}
+	if previous := g.previous.Messages[t.Name()]; previous != nil {
+		locked = numbering{
+			Numbers:       previous.Fields,
+			Reserved:      previous.Reserved,
+			ReservedNames: previous.ReservedNames,
+		}
+	}
+	numbering, problems := renumber(t.Name(), names, locked, nil)
+	g.problems = append(g.problems, problems...)
+	g.lock.Messages[t.Name()] = &MessageLock{
+		Fields:        numbering.Numbers,
+		Reserved:      numbering.Reserved,
+		ReservedNames: numbering.ReservedNames,
+	}
+
+	g.writeComment("", description)
+	fmt.Fprintf(&g.body, "message %s {\n", typeName(t.Name()))
+	if deprecated != nil {
+		g.body.WriteString("  option deprecated = true;\n")
+	}
+	g.writeReserved(numbering, export.SnakeCase)
+	for _, field := range fields {
+		fieldType, isOptional := g.fieldType(field)
+		label := ""
+		if field.IsList {
+			label = "repeated "
+		} else if isOptional {
+			label = "optional "
+		}
+		options := ""
+		if field.Deprecated != nil {
+			options = " [deprecated = true]"
+		}
+		g.writeComment("  ", field.Description)
+		fmt.Fprintf(
+			&g.body,
+			"  %s%s %s = %d%s;\n",
+			label,
+			fieldType,
+			export.SnakeCase(field.Name),
+			numbering.Numbers[field.Name],
+			options,
+		)
+	}
+	g.body.WriteString("}\n\n")
+}
@@ -0,0 +1,202 @@
+package proto
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"sort"
+
+	"github.com/go-yaml/yaml"
+)
+
+// Field numbers reserved for the protobuf implementation
+const (
+	firstImplementationNumber = 19000
+	lastImplementationNumber  = 19999
+)
+
+// maxFieldNumber is the greatest valid field number
+const maxFieldNumber = 1<<29 - 1
+
+// MessageLock represents the field numbers of a message
+type MessageLock struct {
+	// Fields maps the field names to their numbers
+	Fields map[string]int32 `yaml:"fields"`
+
+	// Reserved lists the numbers of removed fields
+	// which must never be reused
+	Reserved []int32 `yaml:"reserved,omitempty"`
+
+	// ReservedNames lists the names of removed fields
+	ReservedNames []string `yaml:"reserved names,omitempty"`
+}
+
+// EnumLock represents the value numbers of an enum
+type EnumLock struct {
+	// Values maps the enumeration item names to their numbers
+	Values map[string]int32 `yaml:"values"`
+
+	// Reserved lists the numbers of removed values
+	// which must never be reused
+	Reserved []int32 `yaml:"reserved,omitempty"`
+
+	// ReservedNames lists the names of removed values
+	ReservedNames []string `yaml:"reserved names,omitempty"`
+}
+
+// Lock represents the persisted field and value numbers
+// keeping the generated messages and enums wire compatible
+// across schema edits
+type Lock struct {
+	Messages map[string]*MessageLock `yaml:"messages"`
+	Enums    map[string]*EnumLock    `yaml:"enums"`
+}
+
+// NewLock returns an empty lock
+func NewLock() *Lock {
+	return &Lock{
+		Messages: make(map[string]*MessageLock),
+		Enums:    make(map[string]*EnumLock),
+	}
+}
+
+// ReadLockFile reads the lock file located at the given path.
+// Returns an empty lock if the file doesn't exist
+func ReadLockFile(path string) (*Lock, error) {
+	buf, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return NewLock(), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read lock file: %s", err)
+	}
+	lock := NewLock()
+	if err := yaml.UnmarshalStrict(buf, lock); err != nil {
+		return nil, fmt.Errorf("couldn't parse lock file: %s", err)
+	}
+	if lock.Messages == nil {
+		lock.Messages = make(map[string]*MessageLock)
+	}
+	if lock.Enums == nil {
+		lock.Enums = make(map[string]*EnumLock)
+	}
+	return lock, nil
+}
+
+// Marshal encodes the lock
+func (l *Lock) Marshal() ([]byte, error) {
+	return yaml.Marshal(l)
+}
+
+// IsOutdated returns true if the given encoded lock file
+// differs from the encoded lock, otherwise returns false
+func (l *Lock) IsOutdated(lockFile []byte) (bool, error) {
+	encoded, err := l.Marshal()
+	if err != nil {
+		return false, err
+	}
+	return !bytes.Equal(lockFile, encoded), nil
+}
+
+// sortedNames returns the names of the given numbers
+// in alphabetical order
+func sortedNames(numbers map[string]int32) []string {
+	names := make([]string, 0, len(numbers))
+	for name := range numbers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// verifyNumbers returns the problems of the given numbers
+// assigned twice or reusing a reserved number
+func verifyNumbers(
+	kind string,
+	owner string,
+	numbers map[string]int32,
+	reserved []int32,
+) []string {
+	problems := make([]string, 0)
+	reservedNumbers := make(map[int32]bool, len(reserved))
+	for _, number := range reserved {
+		reservedNumbers[number] = true
+	}
+
+	assigned := make(map[int32]string, len(numbers))
+	for _, name := range sortedNames(numbers) {
+		number := numbers[name]
+		if reservedNumbers[number] {
+			problems = append(problems, fmt.Sprintf(
+				"%s '%s' of '%s' reuses the removed number %d",
+				kind,
+				name,
+				owner,
+				number,
+			))
+		}
+		if other, isAssigned := assigned[number]; isAssigned {
+			problems = append(problems, fmt.Sprintf(
+				"%ss '%s' and '%s' of '%s' share the number %d",
+				kind,
+				other,
+				name,
+				owner,
+				number,
+			))
+		}
+		assigned[number] = name
+	}
+	return problems
+}
+
+// Verify returns the problems of the lock such as field numbers
+// reusing the numbers of removed fields
+func (l *Lock) Verify() []string {
+	problems := make([]string, 0)
+
+	messageNames := make([]string, 0, len(l.Messages))
+	for name := range l.Messages {
+		messageNames = append(messageNames, name)
+	}
+	sort.Strings(messageNames)
+	for _, name := range messageNames {
+		message := l.Messages[name]
+		for _, fieldName := range sortedNames(message.Fields) {
+			number := message.Fields[fieldName]
+			if number < 1 || number > maxFieldNumber ||
+				number >= firstImplementationNumber &&
+					number <= lastImplementationNumber {
+				problems = append(problems, fmt.Sprintf(
+					"field '%s' of '%s' has the invalid number %d",
+					fieldName,
+					name,
+					number,
+				))
+			}
+		}
+		problems = append(problems, verifyNumbers(
+			"field",
+			name,
+			message.Fields,
+			message.Reserved,
+		)...)
+	}
+
+	enumNames := make([]string, 0, len(l.Enums))
+	for name := range l.Enums {
+		enumNames = append(enumNames, name)
+	}
+	sort.Strings(enumNames)
+	for _, name := range enumNames {
+		enum := l.Enums[name]
+		problems = append(problems, verifyNumbers(
+			"value",
+			name,
+			enum.Values,
+			enum.Reserved,
+		)...)
+	}
+	return problems
+}
@@ -0,0 +1,122 @@
+package proto
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// generate generates the proto schema of the entity type "Movie"
+// declaring the given fields of type String
+func generate(t *testing.T, lock *Lock, fields ...string) *Lock {
+	t.Helper()
+	schema := "title: Test\n" +
+		"scalar types:\n" +
+		"  String:\n" +
+		"    kind: string\n" +
+		"entity types:\n" +
+		"  Movie:\n" +
+		"    meta:\n"
+	for _, field := range fields {
+		schema += "      " + field + ":\n" +
+			"        type: String\n"
+	}
+	doc, _, err := document.New([]byte(schema))
+	if err != nil {
+		t.Fatalf("couldn't parse schema: %s", err)
+	}
+	model, errs, _, err := rend.NewModel(doc, time.Time{})
+	if err != nil || errs.HasErrors() {
+		t.Fatalf("couldn't initialize model: %s %#v", err, errs)
+	}
+	_, updated, err := Generate(model, DefaultOptions(), lock)
+	if err != nil {
+		t.Fatalf("couldn't generate: %s", err)
+	}
+	return updated
+}
+
+// TestLockRemovedField verifies that the numbers and names
+// of removed fields are reserved
+func TestLockRemovedField(t *testing.T) {
+	lock := generate(t, nil, "title", "year")
+	lock = generate(t, lock, "title")
+
+	message := lock.Messages["Movie"]
+	if !reflect.DeepEqual(message.Fields, map[string]int32{"title": 1}) {
+		t.Fatalf("unexpected fields: %v", message.Fields)
+	}
+	if !reflect.DeepEqual(message.Reserved, []int32{2}) {
+		t.Fatalf("unexpected reserved numbers: %v", message.Reserved)
+	}
+	if !reflect.DeepEqual(message.ReservedNames, []string{"year"}) {
+		t.Fatalf("unexpected reserved names: %v", message.ReservedNames)
+	}
+}
+
+// TestLockReaddedField verifies that fields added again
+// are assigned a fresh number
+func TestLockReaddedField(t *testing.T) {
+	lock := generate(t, nil, "title", "year")
+	lock = generate(t, lock, "title")
+	lock = generate(t, lock, "title", "year")
+
+	message := lock.Messages["Movie"]
+	if number := message.Fields["year"]; number != 3 {
+		t.Fatalf("expected the fresh number 3, got %d", number)
+	}
+	if !reflect.DeepEqual(message.Reserved, []int32{2}) {
+		t.Fatalf("unexpected reserved numbers: %v", message.Reserved)
+	}
+	if len(message.ReservedNames) > 0 {
+		t.Fatalf("unexpected reserved names: %v", message.ReservedNames)
+	}
+}
+
+// TestLockIsOutdated verifies that locks are outdated
+// only if the numbering changed
+func TestLockIsOutdated(t *testing.T) {
+	lock := generate(t, nil, "title")
+	lockFile, err := lock.Marshal()
+	if err != nil {
+		t.Fatalf("couldn't encode lock: %s", err)
+	}
+
+	isOutdated, err := generate(t, lock, "title").IsOutdated(lockFile)
+	if err != nil {
+		t.Fatalf("couldn't encode lock: %s", err)
+	}
+	if isOutdated {
+		t.Fatalf("expected an unchanged schema not to outdate the lock")
+	}
+
+	isOutdated, err = generate(t, lock, "title", "year").IsOutdated(lockFile)
+	if err != nil {
+		t.Fatalf("couldn't encode lock: %s", err)
+	}
+	if !isOutdated {
+		t.Fatalf("expected an added field to outdate the lock")
+	}
+}
+
+// TestLockVerify verifies that reused and shared numbers are reported
+// in a deterministic order
+func TestLockVerify(t *testing.T) {
+	lock := NewLock()
+	lock.Messages["Movie"] = &MessageLock{
+		Fields:   map[string]int32{"title": 1, "rating": 2, "year": 2},
+		Reserved: []int32{1},
+	}
+	expected := []string{
+		"field 'title' of 'Movie' reuses the removed number 1",
+		"fields 'rating' and 'year' of 'Movie' share the number 2",
+	}
+	for i := 0; i < 10; i++ {
+		if problems := lock.Verify(); !reflect.DeepEqual(problems, expected) {
+			t.Fatalf("unexpected problems: %#v", problems)
+		}
+	}
+}
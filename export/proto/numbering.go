@@ -0,0 +1,105 @@
+package proto
+
+import (
+	"fmt"
+	"sort"
+)
+
+// numbering represents the numbers of the fields of a message
+// or the values of an enum
+type numbering struct {
+	Numbers       map[string]int32
+	Reserved      []int32
+	ReservedNames []string
+}
+
+// renumber returns the numbering of the given names keeping the locked
+// numbers of the names and reserving the numbers of the removed names.
+// New names are assigned unused numbers unless they're fixed.
+// Returns problems for fixed numbers deviating from their locked numbers
+func renumber(
+	owner string,
+	names []string,
+	locked numbering,
+	fixed map[string]int32,
+) (numbering, []string) {
+	problems := make([]string, 0)
+	present := make(map[string]bool, len(names))
+	for _, name := range names {
+		present[name] = true
+	}
+
+	updated := numbering{
+		Numbers:       make(map[string]int32, len(names)),
+		Reserved:      append([]int32{}, locked.Reserved...),
+		ReservedNames: make([]string, 0, len(locked.ReservedNames)),
+	}
+	reservedNames := make(map[string]bool)
+	for _, name := range locked.ReservedNames {
+		// Revived names are no longer reserved
+		if !present[name] && !reservedNames[name] {
+			reservedNames[name] = true
+			updated.ReservedNames = append(updated.ReservedNames, name)
+		}
+	}
+
+	// Reserve the numbers of the removed names
+	lockedNames := make([]string, 0, len(locked.Numbers))
+	for name := range locked.Numbers {
+		lockedNames = append(lockedNames, name)
+	}
+	sort.Strings(lockedNames)
+	var last int32
+	for _, name := range lockedNames {
+		number := locked.Numbers[name]
+		if number > last {
+			last = number
+		}
+		if present[name] {
+			continue
+		}
+		updated.Reserved = append(updated.Reserved, number)
+		if !reservedNames[name] {
+			reservedNames[name] = true
+			updated.ReservedNames = append(updated.ReservedNames, name)
+		}
+	}
+	for _, number := range locked.Reserved {
+		if number > last {
+			last = number
+		}
+	}
+
+	for _, name := range names {
+		lockedNumber, isLocked := locked.Numbers[name]
+		if number, isFixed := fixed[name]; isFixed {
+			if isLocked && number != lockedNumber {
+				problems = append(problems, fmt.Sprintf(
+					"value '%s' of '%s' changed its number from %d to %d",
+					name,
+					owner,
+					lockedNumber,
+					number,
+				))
+			}
+			updated.Numbers[name] = number
+			continue
+		}
+		if isLocked {
+			updated.Numbers[name] = lockedNumber
+			continue
+		}
+		last++
+		if last >= firstImplementationNumber &&
+			last <= lastImplementationNumber {
+			last = lastImplementationNumber + 1
+		}
+		updated.Numbers[name] = last
+	}
+
+	sort.Slice(updated.Reserved, func(i, j int) bool {
+		return updated.Reserved[i] < updated.Reserved[j]
+	})
+	sort.Strings(updated.ReservedNames)
+	return updated, problems
+}
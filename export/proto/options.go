@@ -0,0 +1,55 @@
+package proto
+
+import (
+	"fmt"
+)
+
+// NullableMode defines how nullable scalar fields are declared
+type NullableMode uint8
+
+const (
+	// OptionalFields declares nullable scalar fields as optional fields
+	OptionalFields NullableMode = iota
+
+	// WrapperTypes declares nullable scalar fields
+	// using the well-known wrapper types such as google.protobuf.StringValue
+	WrapperTypes
+)
+
+// String stringifies the value
+func (m NullableMode) String() string {
+	switch m {
+	case OptionalFields:
+		return "optional"
+	case WrapperTypes:
+		return "wrappers"
+	}
+	panic(fmt.Errorf("couldn't stringify invalid NullableMode value: %d", m))
+}
+
+// FromString initializes the value from a string
+func (m *NullableMode) FromString(str string) error {
+	switch str {
+	case "optional":
+		*m = OptionalFields
+		return nil
+	case "wrappers":
+		*m = WrapperTypes
+		return nil
+	}
+	return fmt.Errorf("invalid nullable mode: '%s'", str)
+}
+
+// Options represents the options of the proto3 generator
+type Options struct {
+	// Package is the name of the generated package,
+	// it's derived from the document title if empty
+	Package string
+
+	Nullable NullableMode
+}
+
+// DefaultOptions returns the default generator options
+func DefaultOptions() Options {
+	return Options{Nullable: OptionalFields}
+}
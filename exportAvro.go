@@ -0,0 +1,83 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/romshark/TypeBook/export/avro"
+)
+
+// exportAvro generates Avro schemas from a schema document
+func exportAvro(args []string) {
+	options := avro.DefaultOptions()
+	flags := flag.NewFlagSet("export-avro", flag.ExitOnError)
+	outputFilePath := flags.String(
+		"o",
+		"",
+		"Output file path of the schema array (defaults to stdout)",
+	)
+	outputDirPath := flags.String(
+		"d",
+		"",
+		"Output directory of self-contained .avsc files per record",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	flags.StringVar(
+		&options.Namespace,
+		"namespace",
+		"",
+		"Namespace (derived from the document title by default)",
+	)
+	flags.Var(
+		scalarTypeMap(options.LogicalTypes),
+		"logical-type",
+		"Logical type of a scalar type such as Identifier=uuid (repeatable)",
+	)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		log.Fatalf("Missing schema file path")
+	}
+	model, _ := loadModel(flags.Arg(0), *format)
+
+	if *outputDirPath != "" {
+		records, err := avro.Records(model, options)
+		if err != nil {
+			log.Fatalf("Couldn't generate Avro schemas: %s", err)
+		}
+		if err := os.MkdirAll(*outputDirPath, 0755); err != nil {
+			log.Fatalf("Couldn't create output directory: %s", err)
+		}
+		for _, record := range records {
+			if err := ioutil.WriteFile(
+				filepath.Join(*outputDirPath, record.Name+".avsc"),
+				append(record.Schema, '\n'),
+				0644,
+			); err != nil {
+				log.Fatalf("Couldn't write Avro schema to file: %s", err)
+			}
+		}
+		return
+	}
+
+	schema, err := avro.Generate(model, options)
+	if err != nil {
+		log.Fatalf("Couldn't generate Avro schemas: %s", err)
+	}
+	schema = append(schema, '\n')
+
+	if *outputFilePath == "" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := ioutil.WriteFile(*outputFilePath, schema, 0644); err != nil {
+		log.Fatalf("Couldn't write Avro schemas to file: %s", err)
+	}
+}
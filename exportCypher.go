@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/romshark/TypeBook/compiler"
+	"github.com/romshark/TypeBook/export/cypher"
+)
+
+// exportCypher generates Cypher schema statements from a schema document
+// or checks a graph database schema for drift
+func exportCypher(args []string) {
+	options := cypher.DefaultOptions()
+	flags := flag.NewFlagSet("export-cypher", flag.ExitOnError)
+	outputFilePath := flags.String(
+		"o",
+		"",
+		"Cypher output file path (defaults to stdout)",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	checkFilePath := flags.String(
+		"check",
+		"",
+		"JSON export of db.schema.visualization() to check for drift",
+	)
+	flags.BoolVar(
+		&options.ExistenceConstraints,
+		"existence-constraints",
+		options.ExistenceConstraints,
+		"Generate property existence constraints (Enterprise Edition)",
+	)
+	flags.BoolVar(
+		&options.TypeConstraints,
+		"type-constraints",
+		options.TypeConstraints,
+		"Generate property type constraints (Enterprise Edition)",
+	)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		log.Fatalf("Missing schema file path")
+	}
+	schemaFilePath := flags.Arg(0)
+	model, source := loadModel(schemaFilePath, *format)
+
+	if *checkFilePath != "" {
+		drift, err := cypher.DriftFile(model, *checkFilePath)
+		if err != nil {
+			log.Fatalf("Couldn't check for drift: %s", err)
+		}
+		printDiagnostics(*format, compiler.NewDiagnostics(
+			schemaFilePath,
+			source,
+			drift,
+		))
+		if len(drift) > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	statements, err := cypher.Generate(model, options)
+	if err != nil {
+		log.Fatalf("Couldn't generate Cypher: %s", err)
+	}
+	statements = append(statements, '\n')
+
+	if *outputFilePath == "" {
+		os.Stdout.Write(statements)
+		return
+	}
+	if err := ioutil.WriteFile(*outputFilePath, statements, 0644); err != nil {
+		log.Fatalf("Couldn't write Cypher to file: %s", err)
+	}
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/romshark/TypeBook/export/proto"
+)
+
+// exportProto generates a proto3 schema from a schema document
+func exportProto(args []string) {
+	options := proto.DefaultOptions()
+	flags := flag.NewFlagSet("export-proto", flag.ExitOnError)
+	outputFilePath := flags.String(
+		"o",
+		"",
+		"Proto output file path (defaults to stdout)",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	lockFilePath := flags.String(
+		"lock",
+		"",
+		"Field number lock file path, it's created if it doesn't exist",
+	)
+	check := flags.Bool(
+		"check",
+		false,
+		"Fail instead of updating an outdated lock file",
+	)
+	flags.StringVar(
+		&options.Package,
+		"package",
+		"",
+		"Package name (derived from the document title by default)",
+	)
+	nullable := flags.String(
+		"nullable",
+		options.Nullable.String(),
+		"Declaration of nullable scalar fields (optional or wrappers)",
+	)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		log.Fatalf("Missing schema file path")
+	}
+	if err := options.Nullable.FromString(*nullable); err != nil {
+		log.Fatalf("Invalid nullable option: %s", err)
+	}
+
+	lock := proto.NewLock()
+	if *lockFilePath != "" {
+		var err error
+		if lock, err = proto.ReadLockFile(*lockFilePath); err != nil {
+			log.Fatalf("Couldn't read lock: %s", err)
+		}
+	}
+
+	model, _ := loadModel(flags.Arg(0), *format)
+	schema, updated, err := proto.Generate(model, options, lock)
+	if err != nil {
+		log.Fatalf("Couldn't generate proto: %s", err)
+	}
+	schema = append(schema, '\n')
+
+	if *lockFilePath != "" {
+		current, _ := ioutil.ReadFile(*lockFilePath)
+		isOutdated, err := updated.IsOutdated(current)
+		if err != nil {
+			log.Fatalf("Couldn't encode lock: %s", err)
+		}
+		if isOutdated {
+			if *check {
+				log.Fatalf("Lock file %s is out of date", *lockFilePath)
+			}
+			encoded, err := updated.Marshal()
+			if err != nil {
+				log.Fatalf("Couldn't encode lock: %s", err)
+			}
+			if err := ioutil.WriteFile(
+				*lockFilePath,
+				encoded,
+				0644,
+			); err != nil {
+				log.Fatalf("Couldn't write lock file: %s", err)
+			}
+		}
+	}
+
+	if *outputFilePath == "" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := ioutil.WriteFile(*outputFilePath, schema, 0644); err != nil {
+		log.Fatalf("Couldn't write proto to file: %s", err)
+	}
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/romshark/TypeBook/export/postgres"
+)
+
+// exportSQL generates a PostgreSQL schema from a schema document
+func exportSQL(args []string) {
+	options := postgres.DefaultOptions()
+	flags := flag.NewFlagSet("export-sql", flag.ExitOnError)
+	outputFilePath := flags.String(
+		"o",
+		"",
+		"SQL output file path (defaults to stdout)",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	composites := flags.String(
+		"composites",
+		options.Composites.String(),
+		"Storage of composite-typed fields (jsonb or flatten)",
+	)
+	relations := flags.String(
+		"relations",
+		options.Relations.String(),
+		"Storage of relations (join-tables or foreign-keys)",
+	)
+	flags.Var(
+		scalarTypeMap(options.ScalarTypes),
+		"scalar-type",
+		"Column type of a scalar type such as Identifier=uuid (repeatable)",
+	)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		log.Fatalf("Missing schema file path")
+	}
+	if err := options.Composites.FromString(*composites); err != nil {
+		log.Fatalf("Invalid composites option: %s", err)
+	}
+	if err := options.Relations.FromString(*relations); err != nil {
+		log.Fatalf("Invalid relations option: %s", err)
+	}
+
+	model, _ := loadModel(flags.Arg(0), *format)
+	schema, err := postgres.Generate(model, options)
+	if err != nil {
+		log.Fatalf("Couldn't generate SQL: %s", err)
+	}
+	schema = append(schema, '\n')
+
+	if *outputFilePath == "" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := ioutil.WriteFile(*outputFilePath, schema, 0644); err != nil {
+		log.Fatalf("Couldn't write SQL to file: %s", err)
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/romshark/TypeBook/compiler"
+	"github.com/romshark/TypeBook/importer/gosource"
+)
+
+// importGo derives a schema document from the types of a Go package
+func importGo(args []string) {
+	flags := flag.NewFlagSet("import-go", flag.ExitOnError)
+	outputFilePath := flags.String(
+		"o",
+		"",
+		"YAML output file path (defaults to stdout)",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	entityMarker := flags.String(
+		"entity-marker",
+		gosource.DefaultEntityMarker,
+		"Doc comment line marking struct types as entity types",
+	)
+	entityTag := flags.String(
+		"entity-tag",
+		gosource.DefaultEntityTag,
+		"Struct tag key marking struct types with a field "+
+			"tagged \"id\" as entity types",
+	)
+	flags.Parse(args)
+
+	packageDir := "."
+	if flags.NArg() > 0 {
+		packageDir = flags.Arg(0)
+	}
+
+	schema, errs, err := gosource.Import(packageDir, gosource.Options{
+		EntityMarker: *entityMarker,
+		EntityTag:    *entityTag,
+	})
+	if err != nil {
+		log.Fatalf("Couldn't import Go package: %s", err)
+	}
+
+	// Keep stdout reserved for the schema
+	writeDiagnostics(
+		os.Stderr,
+		*format,
+		compiler.NewDiagnostics(packageDir, nil, errs),
+	)
+
+	if *outputFilePath == "" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := ioutil.WriteFile(*outputFilePath, schema, 0644); err != nil {
+		log.Fatalf("Couldn't write schema to file: %s", err)
+	}
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/romshark/TypeBook/compiler"
+	"github.com/romshark/TypeBook/importer/sqlddl"
+)
+
+// importSQL derives a schema document from PostgreSQL DDL statements
+func importSQL(args []string) {
+	flags := flag.NewFlagSet("import-sql", flag.ExitOnError)
+	outputFilePath := flags.String(
+		"o",
+		"",
+		"YAML output file path (defaults to stdout)",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	collapseJoinTables := flags.Bool(
+		"collapse-join-tables",
+		false,
+		"Collapse join tables into many-to-many relations",
+	)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		log.Fatalf("Missing SQL file path")
+	}
+	inputFilePath := flags.Arg(0)
+
+	schema, errs, err := sqlddl.ImportFile(inputFilePath, sqlddl.Options{
+		CollapseJoinTables: *collapseJoinTables,
+	})
+	if err != nil {
+		log.Fatalf("Couldn't import SQL: %s", err)
+	}
+
+	// Keep stdout reserved for the schema
+	writeDiagnostics(
+		os.Stderr,
+		*format,
+		compiler.NewDiagnostics(inputFilePath, nil, errs),
+	)
+
+	if *outputFilePath == "" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := ioutil.WriteFile(*outputFilePath, schema, 0644); err != nil {
+		log.Fatalf("Couldn't write schema to file: %s", err)
+	}
+}
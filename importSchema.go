@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"io/ioutil"
+	"log"
+	"os"
+
+	"github.com/romshark/TypeBook/compiler"
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/importer/jsonschema"
+)
+
+// importSchema derives a schema document from the definitions
+// of a JSON Schema or the component schemas of an OpenAPI document
+func importSchema(args []string) {
+	flags := flag.NewFlagSet("import-schema", flag.ExitOnError)
+	outputFilePath := flags.String(
+		"o",
+		"",
+		"YAML output file path (defaults to stdout)",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		log.Fatalf("Missing JSON Schema or OpenAPI file path")
+	}
+	inputFilePath := flags.Arg(0)
+
+	schema, errs, err := jsonschema.ImportFile(inputFilePath)
+	if err != nil {
+		log.Fatalf("Couldn't import schema: %s", err)
+	}
+
+	// Keep stdout reserved for the schema
+	source, _ := ioutil.ReadFile(inputFilePath)
+	writeDiagnostics(
+		os.Stderr,
+		*format,
+		compiler.NewDiagnostics(inputFilePath, document.NewSourceMap(source), errs),
+	)
+
+	if *outputFilePath == "" {
+		os.Stdout.Write(schema)
+		return
+	}
+	if err := ioutil.WriteFile(*outputFilePath, schema, 0644); err != nil {
+		log.Fatalf("Couldn't write schema to file: %s", err)
+	}
+}
@@ -0,0 +1,69 @@
+// Package importer provides the declarations shared by the importers
+// deriving schema documents from other schema languages
+package importer
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// TypeNamePattern matches names that satisfy the default type naming rule
+var TypeNamePattern = regexp.MustCompile(document.DefaultTypeNameRule)
+
+// nameSeparatorPattern matches the characters
+// that are not allowed in type names
+var nameSeparatorPattern = regexp.MustCompile("[^A-Za-z0-9]+")
+
+// TypeName converts the given name to a type name
+// such as "pet-store_2" to "PetStore2"
+func TypeName(name string) string {
+	var builder strings.Builder
+	for _, part := range nameSeparatorPattern.Split(name, -1) {
+		if part == "" {
+			continue
+		}
+		builder.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return builder.String()
+}
+
+// UniqueTypeName returns the given type name numbered
+// such as "Item2" if it's already declared
+func UniqueTypeName(name string, declared map[string]bool) string {
+	unique := name
+	for number := 2; declared[unique]; number++ {
+		unique = name + strconv.Itoa(number)
+	}
+	return unique
+}
+
+// Scalar represents a built-in scalar type imported types are mapped to
+type Scalar struct {
+	Name        string
+	Kind        document.ScalarKind
+	Description string
+}
+
+// BuiltinScalars lists the built-in scalar types in the order
+// they're declared in imported schema documents
+var BuiltinScalars = []Scalar{
+	{"String", document.StringKind, "A UTF8 encoded text value"},
+	{"Integer", document.IntegerKind, "A signed or unsigned integer"},
+	{"Number", document.NumberKind, "A floating point number"},
+	{"Boolean", document.BooleanKind, "Either true or false"},
+	{"Time", document.TimeKind, "An RFC3339 encoded datetime"},
+}
+
+// BuiltinScalar returns the built-in scalar type of the given name
+// and true if it exists, otherwise returns false
+func BuiltinScalar(name string) (Scalar, bool) {
+	for _, scalar := range BuiltinScalars {
+		if scalar.Name == name {
+			return scalar, true
+		}
+	}
+	return Scalar{}, false
+}
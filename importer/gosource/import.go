@@ -0,0 +1,682 @@
+package gosource
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/go-yaml/yaml"
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/importer"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// basicTypes maps Go basic types to built-in scalar types
+var basicTypes = map[string]string{
+	"string":  "String",
+	"bool":    "Boolean",
+	"int":     "Integer",
+	"int8":    "Integer",
+	"int16":   "Integer",
+	"int32":   "Integer",
+	"int64":   "Integer",
+	"uint":    "Integer",
+	"uint8":   "Integer",
+	"uint16":  "Integer",
+	"uint32":  "Integer",
+	"uint64":  "Integer",
+	"uintptr": "Integer",
+	"byte":    "Integer",
+	"rune":    "Integer",
+	"float32": "Number",
+	"float64": "Number",
+}
+
+// declaredType represents an exported type declared in the package
+type declaredType struct {
+	Name string
+	Spec *ast.TypeSpec
+	Doc  *ast.CommentGroup
+}
+
+// fieldType represents the mapped type of a struct field
+type fieldType struct {
+	Name     string
+	IsList   bool
+	Nullable bool
+}
+
+// String returns the TypeBook data type notation of the field type
+func (t fieldType) String() string {
+	return document.DataType{Name: t.Name, IsList: t.IsList}.String()
+}
+
+// packageImporter represents the state of a package import
+type packageImporter struct {
+	options     Options
+	packageName string
+	packageDoc  string
+	types       map[string]*declaredType
+	order       []string
+	constants   map[string][]string
+	entities    map[string]bool
+	usedScalars map[string]bool
+	fileSet     *token.FileSet
+	diagnostics rend.ModelErrors
+}
+
+// report reports a declaration at the given position
+// that couldn't be mapped
+func (imp *packageImporter) report(
+	pos token.Pos,
+	format string,
+	args ...interface{},
+) {
+	position := imp.fileSet.Position(pos)
+	imp.diagnostics.AddWarnUnsupportedConstruct(
+		fmt.Sprintf(format, args...),
+		rend.Location{Description: fmt.Sprintf(
+			"%s:%d",
+			filepath.Base(position.Filename),
+			position.Line,
+		)},
+	)
+}
+
+// Import loads the Go package located in the given directory
+// and returns a TypeBook schema document derived from its types
+// and diagnostics about the declarations that couldn't be mapped
+func Import(dir string, options Options) (
+	schema []byte,
+	diagnostics rend.ModelErrors,
+	err error,
+) {
+	imp := &packageImporter{
+		options:     options,
+		types:       make(map[string]*declaredType),
+		constants:   make(map[string][]string),
+		entities:    make(map[string]bool),
+		usedScalars: make(map[string]bool),
+		fileSet:     token.NewFileSet(),
+	}
+	if err := imp.load(dir); err != nil {
+		return nil, nil, err
+	}
+
+	schema, err = yaml.Marshal(imp.document())
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't encode schema: %s", err)
+	}
+
+	// Make sure the schema is readable
+	if _, _, err := document.New(schema); err != nil {
+		return nil, nil, fmt.Errorf("generated an unreadable schema: %s", err)
+	}
+	return schema, imp.diagnostics, nil
+}
+
+// load parses the non-test Go files of the package
+// located in the given directory
+func (imp *packageImporter) load(dir string) error {
+	infos, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("couldn't read package directory: %s", err)
+	}
+
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() ||
+			!strings.HasSuffix(name, ".go") ||
+			strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		file, err := parser.ParseFile(
+			imp.fileSet,
+			filepath.Join(dir, name),
+			nil,
+			parser.ParseComments,
+		)
+		if err != nil {
+			return fmt.Errorf("couldn't parse Go file: %s", err)
+		}
+		if imp.packageName == "" {
+			imp.packageName = file.Name.Name
+		} else if file.Name.Name != imp.packageName {
+			return fmt.Errorf(
+				"multiple packages in %s: %s and %s",
+				dir,
+				imp.packageName,
+				file.Name.Name,
+			)
+		}
+		if file.Doc != nil && imp.packageDoc == "" {
+			imp.packageDoc = strings.TrimSpace(file.Doc.Text())
+		}
+		imp.collect(file)
+	}
+	if imp.packageName == "" {
+		return fmt.Errorf("no Go files in %s", dir)
+	}
+
+	// Determine the entity types once all types are known
+	for _, typeName := range imp.order {
+		declared := imp.types[typeName]
+		if _, isStruct := declared.Spec.Type.(*ast.StructType); isStruct &&
+			imp.isEntity(declared) {
+			imp.entities[typeName] = true
+		}
+	}
+	return nil
+}
+
+// collect collects the exported type declarations
+// and the string constants declared in the given file
+func (imp *packageImporter) collect(file *ast.File) {
+	for _, decl := range file.Decls {
+		genDecl, isGenDecl := decl.(*ast.GenDecl)
+		if !isGenDecl {
+			continue
+		}
+		switch genDecl.Tok {
+		case token.TYPE:
+			for _, spec := range genDecl.Specs {
+				typeSpec := spec.(*ast.TypeSpec)
+				if !typeSpec.Name.IsExported() {
+					continue
+				}
+				doc := typeSpec.Doc
+				if doc == nil && len(genDecl.Specs) == 1 {
+					doc = genDecl.Doc
+				}
+				imp.types[typeSpec.Name.Name] = &declaredType{
+					Name: typeSpec.Name.Name,
+					Spec: typeSpec,
+					Doc:  doc,
+				}
+				imp.order = append(imp.order, typeSpec.Name.Name)
+			}
+		case token.CONST:
+			for _, spec := range genDecl.Specs {
+				imp.collectConstant(spec.(*ast.ValueSpec))
+			}
+		}
+	}
+}
+
+// collectConstant collects the string values of the given constant
+// declaration if it's typed by a named type
+func (imp *packageImporter) collectConstant(spec *ast.ValueSpec) {
+	for _, value := range spec.Values {
+		typeName := ""
+		if ident, isIdent := spec.Type.(*ast.Ident); isIdent {
+			typeName = ident.Name
+		}
+
+		// Conversions such as Genre("action")
+		if call, isCall := value.(*ast.CallExpr); isCall && len(call.Args) == 1 {
+			if ident, isIdent := call.Fun.(*ast.Ident); isIdent {
+				typeName = ident.Name
+				value = call.Args[0]
+			}
+		}
+
+		literal, isLiteral := value.(*ast.BasicLit)
+		if typeName == "" || !isLiteral || literal.Kind != token.STRING {
+			continue
+		}
+		str, err := strconv.Unquote(literal.Value)
+		if err != nil || str == "" {
+			continue
+		}
+		imp.constants[typeName] = append(imp.constants[typeName], str)
+	}
+}
+
+// isEntity returns true if the given struct type is marked as an entity
+// type by a doc comment marker or a struct tag, otherwise returns false
+func (imp *packageImporter) isEntity(declared *declaredType) bool {
+	if imp.options.EntityMarker != "" && declared.Doc != nil {
+		for _, line := range strings.Split(declared.Doc.Text(), "\n") {
+			if strings.TrimSpace(line) == imp.options.EntityMarker {
+				return true
+			}
+		}
+	}
+	if imp.options.EntityTag == "" {
+		return false
+	}
+	for _, field := range declared.Spec.Type.(*ast.StructType).Fields.List {
+		if field.Tag == nil {
+			continue
+		}
+		tag, err := strconv.Unquote(field.Tag.Value)
+		if err != nil {
+			continue
+		}
+		if reflect.StructTag(tag).Get(imp.options.EntityTag) == "id" {
+			return true
+		}
+	}
+	return false
+}
+
+// description returns the given doc comment without the entity marker
+func (imp *packageImporter) description(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	lines := make([]string, 0)
+	for _, line := range strings.Split(doc.Text(), "\n") {
+		if imp.options.EntityMarker != "" &&
+			strings.TrimSpace(line) == imp.options.EntityMarker {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// isEnumeration returns true if the given type is a named string type
+// with constants, otherwise returns false
+func (imp *packageImporter) isEnumeration(declared *declaredType) bool {
+	ident, isIdent := declared.Spec.Type.(*ast.Ident)
+	return isIdent &&
+		ident.Name == "string" &&
+		len(imp.constants[declared.Name]) > 0
+}
+
+// scalarKind returns the scalar kind of the given named type
+// and true if it's mapped to a scalar type, otherwise returns false
+func scalarKind(declared *declaredType) (document.ScalarKind, bool) {
+	switch t := declared.Spec.Type.(type) {
+	case *ast.Ident:
+		if scalar, isBasic := importer.BuiltinScalar(basicTypes[t.Name]); isBasic {
+			return scalar.Kind, true
+		}
+	case *ast.SelectorExpr:
+		if isTimeType(t) {
+			return document.TimeKind, true
+		}
+	}
+	return document.AnyKind, false
+}
+
+// isTimeType returns true if the given expression refers to time.Time
+func isTimeType(expr ast.Expr) bool {
+	selector, isSelector := expr.(*ast.SelectorExpr)
+	if !isSelector {
+		return false
+	}
+	pkg, isIdent := selector.X.(*ast.Ident)
+	return isIdent && pkg.Name == "time" && selector.Sel.Name == "Time"
+}
+
+// resolve maps the given Go type expression to a TypeBook field type
+func (imp *packageImporter) resolve(expr ast.Expr, depth int) (fieldType, error) {
+	if depth > 16 {
+		return fieldType{}, fmt.Errorf("type nesting too deep")
+	}
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		resolved, err := imp.resolve(t.X, depth+1)
+		resolved.Nullable = true
+		return resolved, err
+
+	case *ast.ArrayType:
+		if ident, isIdent := t.Elt.(*ast.Ident); isIdent && ident.Name == "byte" {
+			// Byte slices are encoded as strings
+			imp.usedScalars["String"] = true
+			return fieldType{Name: "String"}, nil
+		}
+		resolved, err := imp.resolve(t.Elt, depth+1)
+		if err != nil {
+			return resolved, err
+		}
+		if resolved.IsList {
+			return fieldType{}, fmt.Errorf("nested lists are not supported")
+		}
+		// List items are never nullable
+		return fieldType{Name: resolved.Name, IsList: true}, nil
+
+	case *ast.Ident:
+		if builtin, isBasic := basicTypes[t.Name]; isBasic {
+			imp.usedScalars[builtin] = true
+			return fieldType{Name: builtin}, nil
+		}
+		declared, isDeclared := imp.types[t.Name]
+		if !isDeclared {
+			return fieldType{}, fmt.Errorf("unknown type '%s'", t.Name)
+		}
+		if !importer.TypeNamePattern.MatchString(t.Name) {
+			return fieldType{}, fmt.Errorf("illegal type name '%s'", t.Name)
+		}
+		if _, isStruct := declared.Spec.Type.(*ast.StructType); isStruct {
+			return fieldType{Name: t.Name}, nil
+		}
+		if _, isScalar := scalarKind(declared); isScalar {
+			return fieldType{Name: t.Name}, nil
+		}
+		// Resolve named composite types such as slices
+		return imp.resolve(declared.Spec.Type, depth+1)
+
+	case *ast.SelectorExpr:
+		if isTimeType(t) {
+			imp.usedScalars["Time"] = true
+			return fieldType{Name: "Time"}, nil
+		}
+	}
+	return fieldType{}, fmt.Errorf(
+		"unsupported type '%s'",
+		types.ExprString(expr),
+	)
+}
+
+// fieldName returns the name of the given struct field as encoded
+// in JSON and false if the field isn't encoded
+func fieldName(name string, tag *ast.BasicLit) (string, bool) {
+	if tag != nil {
+		if str, err := strconv.Unquote(tag.Value); err == nil {
+			jsonTag := reflect.StructTag(str).Get("json")
+			jsonName := strings.Split(jsonTag, ",")[0]
+			if jsonName == "-" {
+				return "", false
+			}
+			if jsonName != "" {
+				return jsonName, true
+			}
+		}
+	}
+	return lowerCamelCase(name), true
+}
+
+// mixedCaseInitialisms lists the initialisms
+// that aren't spelled in upper case
+var mixedCaseInitialisms = []string{"OAuth"}
+
+// lowerCamelCase returns the given exported Go name in lower camel case
+// lowercasing leading initialisms as a whole ("SKUCode" -> "skuCode")
+func lowerCamelCase(name string) string {
+	for _, initialism := range mixedCaseInitialisms {
+		if strings.HasPrefix(name, initialism) {
+			return strings.ToLower(initialism) +
+				name[len(initialism):]
+		}
+	}
+
+	runes := []rune(name)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) &&
+		!isPluralSuffix(runes[upper:]) {
+		// The last upper case letter starts the next word
+		upper--
+	}
+	return strings.ToLower(string(runes[:upper])) + string(runes[upper:])
+}
+
+// isPluralSuffix returns true if the given rest of a name following
+// an initialism starts with the plural "s" of the initialism
+// such as in "IDs" or "URLsByHost", otherwise returns false
+func isPluralSuffix(rest []rune) bool {
+	return rest[0] == 's' && (len(rest) == 1 || !unicode.IsLower(rest[1]))
+}
+
+// fieldDescription returns the doc or line comment of the given field
+func fieldDescription(field *ast.Field) string {
+	if field.Doc != nil {
+		return strings.TrimSpace(field.Doc.Text())
+	}
+	if field.Comment != nil {
+		return strings.TrimSpace(field.Comment.Text())
+	}
+	return ""
+}
+
+// structMembers maps the fields of the given struct type to metadata
+// fields and, for entity types, fields referencing entities to relations
+func (imp *packageImporter) structMembers(
+	typeName string,
+	structType *ast.StructType,
+	metadata *yaml.MapSlice,
+	relations *yaml.MapSlice,
+	depth int,
+) {
+	isEntity := imp.entities[typeName]
+	for _, field := range structType.Fields.List {
+		if len(field.Names) < 1 {
+			// Flatten embedded structs
+			embedded := field.Type
+			if star, isStar := embedded.(*ast.StarExpr); isStar {
+				embedded = star.X
+			}
+			ident, isIdent := embedded.(*ast.Ident)
+			if isIdent && imp.types[ident.Name] != nil && depth < 16 {
+				if embeddedStruct, isStruct := imp.types[ident.Name].Spec.Type.(*ast.StructType); isStruct {
+					imp.structMembers(
+						typeName,
+						embeddedStruct,
+						metadata,
+						relations,
+						depth+1,
+					)
+					continue
+				}
+			}
+			imp.report(
+				field.Pos(),
+				"%s: skipped embedded field of type '%s'",
+				typeName,
+				types.ExprString(field.Type),
+			)
+			continue
+		}
+
+		for _, name := range field.Names {
+			if !name.IsExported() {
+				continue
+			}
+			encodedName, isEncoded := fieldName(name.Name, field.Tag)
+			if !isEncoded {
+				continue
+			}
+			resolved, err := imp.resolve(field.Type, 0)
+			if err != nil {
+				imp.report(
+					name.Pos(),
+					"%s.%s: skipped field: %s",
+					typeName,
+					name.Name,
+					err,
+				)
+				continue
+			}
+
+			if imp.entities[resolved.Name] {
+				if !isEntity {
+					imp.report(
+						name.Pos(),
+						"%s.%s: skipped reference to entity type '%s' "+
+							"of a composite type",
+						typeName,
+						name.Name,
+						resolved.Name,
+					)
+					continue
+				}
+				if !importer.TypeNamePattern.MatchString(name.Name) {
+					imp.report(
+						name.Pos(),
+						"%s.%s: skipped relation with illegal type name",
+						typeName,
+						name.Name,
+					)
+					continue
+				}
+				relation := yaml.MapSlice{
+					{Key: "type", Value: name.Name},
+					{Key: "direction", Value: "outbound"},
+					{Key: "related type", Value: resolved.Name},
+				}
+				if description := fieldDescription(field); description != "" {
+					relation = append(relation, yaml.MapItem{
+						Key:   "description",
+						Value: description,
+					})
+				}
+				*relations = append(*relations, yaml.MapItem{
+					Key:   encodedName,
+					Value: relation,
+				})
+				continue
+			}
+
+			typedField := yaml.MapSlice{
+				{Key: "type", Value: resolved.String()},
+			}
+			if description := fieldDescription(field); description != "" {
+				typedField = append(typedField, yaml.MapItem{
+					Key:   "description",
+					Value: description,
+				})
+			}
+			if resolved.Nullable {
+				typedField = append(typedField, yaml.MapItem{
+					Key:   "nullable",
+					Value: true,
+				})
+			}
+			*metadata = append(*metadata, yaml.MapItem{
+				Key:   encodedName,
+				Value: typedField,
+			})
+		}
+	}
+}
+
+// document builds the schema document of the loaded package
+func (imp *packageImporter) document() yaml.MapSlice {
+	scalarTypes := yaml.MapSlice{}
+	enumerationTypes := yaml.MapSlice{}
+	compositeTypes := yaml.MapSlice{}
+	entityTypes := yaml.MapSlice{}
+
+	// withDescription prepends the description to the given declaration
+	withDescription := func(
+		doc *ast.CommentGroup,
+		declaration yaml.MapSlice,
+	) yaml.MapSlice {
+		description := imp.description(doc)
+		if description == "" {
+			return declaration
+		}
+		return append(yaml.MapSlice{
+			{Key: "description", Value: description},
+		}, declaration...)
+	}
+
+	for _, typeName := range imp.order {
+		declared := imp.types[typeName]
+		if !importer.TypeNamePattern.MatchString(typeName) {
+			imp.report(
+				declared.Spec.Pos(),
+				"%s: skipped type with illegal type name",
+				typeName,
+			)
+			continue
+		}
+
+		if structType, isStruct := declared.Spec.Type.(*ast.StructType); isStruct {
+			metadata := yaml.MapSlice{}
+			relations := yaml.MapSlice{}
+			imp.structMembers(typeName, structType, &metadata, &relations, 0)
+			declaration := yaml.MapSlice{{Key: "meta", Value: metadata}}
+			if !imp.entities[typeName] {
+				compositeTypes = append(compositeTypes, yaml.MapItem{
+					Key:   typeName,
+					Value: withDescription(declared.Doc, declaration),
+				})
+				continue
+			}
+			if len(relations) > 0 {
+				declaration = append(declaration, yaml.MapItem{
+					Key:   "relations",
+					Value: relations,
+				})
+			}
+			entityTypes = append(entityTypes, yaml.MapItem{
+				Key:   typeName,
+				Value: withDescription(declared.Doc, declaration),
+			})
+			continue
+		}
+
+		if imp.isEnumeration(declared) {
+			values := yaml.MapSlice{}
+			for _, value := range imp.constants[typeName] {
+				values = append(values, yaml.MapItem{Key: value, Value: value})
+			}
+			enumerationTypes = append(enumerationTypes, yaml.MapItem{
+				Key: typeName,
+				Value: withDescription(declared.Doc, yaml.MapSlice{
+					{Key: "values", Value: values},
+				}),
+			})
+			continue
+		}
+
+		if kind, isScalar := scalarKind(declared); isScalar {
+			scalarTypes = append(scalarTypes, yaml.MapItem{
+				Key: typeName,
+				Value: withDescription(declared.Doc, yaml.MapSlice{
+					{Key: "kind", Value: kind.String()},
+				}),
+			})
+		}
+	}
+
+	// Prepend the used built-in scalar types
+	builtins := yaml.MapSlice{}
+	for _, scalar := range importer.BuiltinScalars {
+		if !imp.usedScalars[scalar.Name] || imp.types[scalar.Name] != nil {
+			continue
+		}
+		builtins = append(builtins, yaml.MapItem{
+			Key: scalar.Name,
+			Value: yaml.MapSlice{
+				{Key: "description", Value: scalar.Description},
+				{Key: "kind", Value: scalar.Kind.String()},
+			},
+		})
+	}
+	scalarTypes = append(builtins, scalarTypes...)
+
+	schema := yaml.MapSlice{{Key: "title", Value: imp.packageName}}
+	if imp.packageDoc != "" {
+		schema = append(schema, yaml.MapItem{
+			Key:   "description",
+			Value: imp.packageDoc,
+		})
+	}
+	for _, section := range []yaml.MapItem{
+		{Key: "scalar types", Value: scalarTypes},
+		{Key: "enumeration types", Value: enumerationTypes},
+		{Key: "composite types", Value: compositeTypes},
+		{Key: "entity types", Value: entityTypes},
+	} {
+		if len(section.Value.(yaml.MapSlice)) > 0 {
+			schema = append(schema, section)
+		}
+	}
+
+	return schema
+}
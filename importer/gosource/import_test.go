@@ -0,0 +1,191 @@
+package gosource
+
+import (
+	"go/ast"
+	"go/token"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// TestFieldName verifies that struct field names are encoded by their
+// JSON tag names or in lower camel case
+func TestFieldName(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		tag      string
+		expected string
+		encoded  bool
+	}{
+		{name: "Name", expected: "name", encoded: true},
+		{name: "ID", expected: "id", encoded: true},
+		{name: "IDs", expected: "ids", encoded: true},
+		{name: "URL", expected: "url", encoded: true},
+		{name: "URLsByHost", expected: "urlsByHost", encoded: true},
+		{name: "SKUCode", expected: "skuCode", encoded: true},
+		{name: "HTTPServer", expected: "httpServer", encoded: true},
+		{name: "OAuth2Token", expected: "oauth2Token", encoded: true},
+		{name: "ÄnderungsDatum", expected: "änderungsDatum", encoded: true},
+		{name: "ÜÖBericht", expected: "üöBericht", encoded: true},
+		{
+			name:     "Title",
+			tag:      "`json:\"movie_title,omitempty\"`",
+			expected: "movie_title",
+			encoded:  true,
+		},
+		{
+			name:     "Rating",
+			tag:      "`json:\",omitempty\"`",
+			expected: "rating",
+			encoded:  true,
+		},
+		{name: "Secret", tag: "`json:\"-\"`"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			var tag *ast.BasicLit
+			if tt.tag != "" {
+				tag = &ast.BasicLit{Kind: token.STRING, Value: tt.tag}
+			}
+			name, encoded := fieldName(tt.name, tag)
+			if name != tt.expected || encoded != tt.encoded {
+				t.Fatalf(
+					"expected (%q, %t), got (%q, %t)",
+					tt.expected,
+					tt.encoded,
+					name,
+					encoded,
+				)
+			}
+		})
+	}
+}
+
+// testPackage is the Go package the import tests are run on
+const testPackage = "// Package movies declares movies\n" +
+	"package movies\n" +
+	"\n" +
+	"import \"time\"\n" +
+	"\n" +
+	"// Genre is a movie genre\n" +
+	"type Genre string\n" +
+	"\n" +
+	"const (\n" +
+	"	Drama  Genre = \"drama\"\n" +
+	"	Comedy Genre = \"comedy\"\n" +
+	")\n" +
+	"\n" +
+	"// Rating is a movie rating\n" +
+	"type Rating struct {\n" +
+	"	Score float64\n" +
+	"	Votes int\n" +
+	"}\n" +
+	"\n" +
+	"// Person is a person\n" +
+	"type Person struct {\n" +
+	"	ID   string `typebook:\"id\"`\n" +
+	"	Name string\n" +
+	"}\n" +
+	"\n" +
+	"// Movie is a movie\n" +
+	"//\n" +
+	"// typebook:entity\n" +
+	"type Movie struct {\n" +
+	"	Title    string\n" +
+	"	Genre    Genre\n" +
+	"	Released time.Time\n" +
+	"	Rating   *Rating\n" +
+	"	Tags     []string\n" +
+	"	SKUCode  string\n" +
+	"	Credits  map[string]string\n" +
+	"	Director Person\n" +
+	"}\n"
+
+// TestImport verifies that the types declared in a Go package
+// are imported as their TypeBook equivalents
+func TestImport(t *testing.T) {
+	dir := t.TempDir()
+	if err := ioutil.WriteFile(
+		filepath.Join(dir, "movies.go"),
+		[]byte(testPackage),
+		0644,
+	); err != nil {
+		t.Fatalf("couldn't write the test package: %s", err)
+	}
+
+	schema, diagnostics, err := Import(dir, DefaultOptions())
+	if err != nil {
+		t.Fatalf("couldn't import: %s", err)
+	}
+	doc, _, err := document.New(schema)
+	if err != nil {
+		t.Fatalf("couldn't parse the imported schema: %s", err)
+	}
+	if len(doc.Problems) > 0 {
+		t.Fatalf("unexpected problems: %#v", doc.Problems)
+	}
+
+	values := doc.EnumerationTypes["Genre"].Values
+	if len(values) != 2 ||
+		values["drama"].Value != "drama" ||
+		values["comedy"].Value != "comedy" {
+		t.Fatalf("unexpected values: %#v", values)
+	}
+	if _, isComposite := doc.CompositeTypes["Rating"]; !isComposite {
+		t.Fatalf("expected 'Rating' to be a composite type")
+	}
+	if _, isEntity := doc.EntityTypes["Person"]; !isEntity {
+		t.Fatalf("expected the tagged 'Person' to be an entity type")
+	}
+
+	movie, isEntity := doc.EntityTypes["Movie"]
+	if !isEntity {
+		t.Fatalf("expected the marked 'Movie' to be an entity type")
+	}
+	if movie.Description != "Movie is a movie" {
+		t.Fatalf("unexpected description: %q", movie.Description)
+	}
+	for name, expected := range map[string]struct {
+		typeName string
+		isList   bool
+		nullable bool
+	}{
+		"title":    {typeName: "String"},
+		"genre":    {typeName: "Genre"},
+		"released": {typeName: "Time"},
+		"rating":   {typeName: "Rating", nullable: true},
+		"tags":     {typeName: "String", isList: true},
+		"skuCode":  {typeName: "String"},
+	} {
+		field, isDeclared := movie.Metadata[name]
+		if !isDeclared ||
+			field.Type.Name != expected.typeName ||
+			field.Type.IsList != expected.isList ||
+			field.Nullable != expected.nullable {
+			t.Fatalf("unexpected field '%s': %#v", name, field)
+		}
+	}
+	if _, isField := movie.Metadata["credits"]; isField {
+		t.Fatalf("expected the map field to be skipped")
+	}
+
+	relation := movie.Relations["director"]
+	if relation.Type != "Director" ||
+		relation.RelatedType != "Person" ||
+		relation.Direction != document.OutboundRelation {
+		t.Fatalf("unexpected relation: %#v", relation)
+	}
+
+	if len(diagnostics) != 1 ||
+		diagnostics[0].Code != rend.ErrUnsupportedConstruct ||
+		!strings.Contains(diagnostics[0].Message, "Movie.Credits") ||
+		!strings.HasPrefix(
+			diagnostics[0].Location.Description,
+			"movies.go:",
+		) {
+		t.Fatalf("unexpected diagnostics: %#v", diagnostics)
+	}
+}
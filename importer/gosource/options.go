@@ -0,0 +1,30 @@
+package gosource
+
+const (
+	// DefaultEntityMarker is the default doc comment line
+	// marking struct types as entity types
+	DefaultEntityMarker = "typebook:entity"
+
+	// DefaultEntityTag is the default struct tag key marking struct types
+	// with a field tagged `typebook:"id"` as entity types
+	DefaultEntityTag = "typebook"
+)
+
+// Options represents the options of the Go source importer
+type Options struct {
+	// EntityMarker is the doc comment line marking struct types
+	// as entity types. Markers are removed from the descriptions
+	EntityMarker string
+
+	// EntityTag is the struct tag key marking struct types as entity types
+	// if any of their fields is tagged with the "id" value
+	EntityTag string
+}
+
+// DefaultOptions returns the default importer options
+func DefaultOptions() Options {
+	return Options{
+		EntityMarker: DefaultEntityMarker,
+		EntityTag:    DefaultEntityTag,
+	}
+}
@@ -0,0 +1,736 @@
+// Package jsonschema derives schema documents from the definitions
+// of JSON Schema documents and the component schemas of OpenAPI documents
+package jsonschema
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/go-yaml/yaml"
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/importer"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// unsupportedKeywords lists the keywords that have no TypeBook equivalent
+var unsupportedKeywords = []string{
+	"allOf",
+	"anyOf",
+	"oneOf",
+	"not",
+	"if",
+	"then",
+	"else",
+	"const",
+	"discriminator",
+	"patternProperties",
+	"dependentSchemas",
+	"dependentRequired",
+	"minItems",
+	"maxItems",
+	"uniqueItems",
+	"minProperties",
+	"maxProperties",
+	"exclusiveMinimum",
+	"exclusiveMaximum",
+	"multipleOf",
+}
+
+// schemaImporter represents the state of an import
+type schemaImporter struct {
+	// definitions maps definition reference prefixes such as
+	// "#/components/schemas/" to the paths of the definitions
+	definitions map[string][]string
+
+	// names maps the references of the definitions to their type names
+	names map[string]string
+
+	// declared keeps track of the declared type names
+	declared map[string]bool
+
+	// formats maps string formats to the names of their scalar types
+	formats map[string]string
+
+	usedScalars      map[string]bool
+	scalarTypes      yaml.MapSlice
+	enumerationTypes yaml.MapSlice
+	compositeTypes   yaml.MapSlice
+	diagnostics      rend.ModelErrors
+}
+
+// location returns the location of the given path as a JSON pointer
+func location(path []string) rend.Location {
+	return rend.Location{
+		Description: "#/" + strings.Join(path, "/"),
+		Path:        path,
+	}
+}
+
+// report reports an unsupported construct at the given path
+func (imp *schemaImporter) report(path []string, format string, args ...interface{}) {
+	imp.diagnostics.AddWarnUnsupportedConstruct(
+		fmt.Sprintf(format, args...),
+		location(path),
+	)
+}
+
+// get returns the value of the given key of the given mapping
+func get(mapping yaml.MapSlice, key string) interface{} {
+	for _, item := range mapping {
+		if fmt.Sprint(item.Key) == key {
+			return item.Value
+		}
+	}
+	return nil
+}
+
+// getMapping returns the mapping of the given key of the given mapping
+// or nil if there's no such mapping
+func getMapping(mapping yaml.MapSlice, key string) yaml.MapSlice {
+	value, _ := get(mapping, key).(yaml.MapSlice)
+	return value
+}
+
+// getString returns the string of the given key of the given mapping
+// or an empty string if there's no such string
+func getString(mapping yaml.MapSlice, key string) string {
+	value, _ := get(mapping, key).(string)
+	return value
+}
+
+// appendPath returns a copy of the given path extended by the given keys
+func appendPath(path []string, keys ...string) []string {
+	extended := make([]string, len(path), len(path)+len(keys))
+	copy(extended, path)
+	return append(extended, keys...)
+}
+
+// ImportFile reads the JSON Schema or OpenAPI document located
+// at the given path. See Import
+func ImportFile(path string) (
+	schema []byte,
+	diagnostics rend.ModelErrors,
+	err error,
+) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't read file: %s", err)
+	}
+	return Import(buf)
+}
+
+// Import reads the given JSON Schema or OpenAPI document (JSON or YAML)
+// and returns a TypeBook schema document derived from its schemas
+// and diagnostics about the constructs that have no TypeBook equivalent
+func Import(buf []byte) (
+	schema []byte,
+	diagnostics rend.ModelErrors,
+	err error,
+) {
+	// YAML is a superset of JSON, both are parsed the same way.
+	// Nested mappings are decoded as ordered mapping slices
+	var root yaml.MapSlice
+	if err := yaml.Unmarshal(buf, &root); err != nil {
+		return nil, nil, fmt.Errorf("couldn't parse file: %s", err)
+	}
+
+	imp := &schemaImporter{
+		definitions: make(map[string][]string),
+		names:       make(map[string]string),
+		declared:    make(map[string]bool),
+		formats:     make(map[string]string),
+		usedScalars: make(map[string]bool),
+	}
+	for _, scalar := range importer.BuiltinScalars {
+		imp.declared[scalar.Name] = true
+	}
+
+	// Find the definitions, OpenAPI documents are also recognized
+	// by their schema components if they lack the version
+	isJSONSchema := false
+	switch {
+	case get(root, "openapi") != nil,
+		get(getMapping(root, "components"), "schemas") != nil:
+		imp.definitions["#/components/schemas/"] = []string{
+			"components",
+			"schemas",
+		}
+	case get(root, "swagger") != nil:
+		imp.definitions["#/definitions/"] = []string{"definitions"}
+	default:
+		isJSONSchema = true
+		imp.definitions["#/$defs/"] = []string{"$defs"}
+		imp.definitions["#/definitions/"] = []string{"definitions"}
+	}
+
+	// Name the definitions before declaring them
+	// to resolve references to definitions declared later on
+	type definition struct {
+		Reference string
+		Path      []string
+		Schema    yaml.MapSlice
+	}
+	definitions := make([]definition, 0)
+	prefixes := make([]string, 0, len(imp.definitions))
+	for prefix := range imp.definitions {
+		prefixes = append(prefixes, prefix)
+	}
+	sort.Strings(prefixes)
+	for _, prefix := range prefixes {
+		section := root
+		for _, key := range imp.definitions[prefix] {
+			section = getMapping(section, key)
+		}
+		for _, item := range section {
+			name := fmt.Sprint(item.Key)
+			path := appendPath(imp.definitions[prefix], name)
+			itemSchema, isSchema := item.Value.(yaml.MapSlice)
+			if !isSchema {
+				imp.report(path, "non-object schema '%s'", name)
+				continue
+			}
+			definitions = append(definitions, definition{
+				Reference: prefix + name,
+				Path:      path,
+				Schema:    itemSchema,
+			})
+			imp.names[prefix+name] = imp.reserve(name, path)
+		}
+	}
+
+	// Import the root schema of JSON Schema documents
+	// if it describes an object
+	if isJSONSchema && get(root, "properties") != nil {
+		title := getString(root, "title")
+		if title == "" {
+			title = "Root"
+		}
+		definitions = append(definitions, definition{
+			Reference: "#",
+			Path:      []string{},
+			Schema:    root,
+		})
+		imp.names["#"] = imp.reserve(title, []string{})
+	}
+
+	if len(definitions) == 0 {
+		imp.report(
+			[]string{},
+			"no schemas found, expected an OpenAPI, Swagger "+
+				"or JSON Schema document",
+		)
+	}
+
+	for _, def := range definitions {
+		if imp.names[def.Reference] == "" {
+			continue
+		}
+		imp.declare(imp.names[def.Reference], def.Schema, def.Path)
+	}
+
+	// Prepend the used built-in scalar types
+	scalarTypes := yaml.MapSlice{}
+	for _, scalar := range importer.BuiltinScalars {
+		if !imp.usedScalars[scalar.Name] {
+			continue
+		}
+		scalarTypes = append(scalarTypes, yaml.MapItem{
+			Key: scalar.Name,
+			Value: yaml.MapSlice{
+				{Key: "description", Value: scalar.Description},
+				{Key: "kind", Value: scalar.Kind.String()},
+			},
+		})
+	}
+	scalarTypes = append(scalarTypes, imp.scalarTypes...)
+
+	doc := yaml.MapSlice{}
+	info := getMapping(root, "info")
+	if title := getString(info, "title"); title != "" {
+		doc = append(doc, yaml.MapItem{Key: "title", Value: title})
+	} else if title := getString(root, "title"); title != "" {
+		doc = append(doc, yaml.MapItem{Key: "title", Value: title})
+	}
+	if version := getString(info, "version"); version != "" {
+		doc = append(doc, yaml.MapItem{Key: "version", Value: version})
+	}
+	if description := getString(info, "description"); description != "" {
+		doc = append(doc, yaml.MapItem{Key: "description", Value: description})
+	} else if description := getString(root, "description"); description != "" {
+		doc = append(doc, yaml.MapItem{Key: "description", Value: description})
+	}
+	for _, section := range []yaml.MapItem{
+		{Key: "scalar types", Value: scalarTypes},
+		{Key: "enumeration types", Value: imp.enumerationTypes},
+		{Key: "composite types", Value: imp.compositeTypes},
+	} {
+		if len(section.Value.(yaml.MapSlice)) > 0 {
+			doc = append(doc, section)
+		}
+	}
+
+	schema, err = yaml.Marshal(doc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't encode schema: %s", err)
+	}
+
+	// Make sure the schema is readable
+	if _, _, err := document.New(schema); err != nil {
+		return nil, nil, fmt.Errorf("generated an unreadable schema: %s", err)
+	}
+	return schema, imp.diagnostics, nil
+}
+
+// reserve returns a unique type name for the schema of the given name
+// or an empty string if the name can't be converted to a type name
+func (imp *schemaImporter) reserve(name string, path []string) string {
+	converted := importer.TypeName(name)
+	if !importer.TypeNamePattern.MatchString(converted) {
+		imp.report(path, "schema name '%s' can't be a type name", name)
+		return ""
+	}
+	unique := importer.UniqueTypeName(converted, imp.declared)
+	if unique != name {
+		imp.diagnostics.AddInfoRenamedType(name, unique, location(path))
+	}
+	imp.declared[unique] = true
+	return unique
+}
+
+// reportUnsupported reports the unsupported keywords
+// used by the given schema
+func (imp *schemaImporter) reportUnsupported(
+	schema yaml.MapSlice,
+	path []string,
+) {
+	for _, keyword := range unsupportedKeywords {
+		if get(schema, keyword) != nil {
+			imp.report(
+				appendPath(path, keyword),
+				"unsupported keyword '%s'",
+				keyword,
+			)
+		}
+	}
+	switch additional := get(schema, "additionalProperties").(type) {
+	case nil:
+	case bool:
+		if additional {
+			imp.report(
+				appendPath(path, "additionalProperties"),
+				"additional properties are not supported",
+			)
+		}
+	default:
+		imp.report(
+			appendPath(path, "additionalProperties"),
+			"additional properties are not supported",
+		)
+	}
+}
+
+// schemaTypes returns the types of the given schema
+// and true if the schema is nullable
+func schemaTypes(schema yaml.MapSlice) (types []string, nullable bool) {
+	switch t := get(schema, "type").(type) {
+	case string:
+		types = []string{t}
+	case []interface{}:
+		for _, item := range t {
+			if name := fmt.Sprint(item); name == "null" {
+				nullable = true
+			} else {
+				types = append(types, name)
+			}
+		}
+	}
+	if isNullable, _ := get(schema, "nullable").(bool); isNullable {
+		nullable = true
+	}
+	if types == nil && get(schema, "properties") != nil {
+		types = []string{"object"}
+	}
+	return types, nullable
+}
+
+// withDescription prepends the description of the given schema
+// to the given declaration
+func withDescription(
+	schema yaml.MapSlice,
+	declaration yaml.MapSlice,
+) yaml.MapSlice {
+	description := strings.TrimSpace(getString(schema, "description"))
+	if description == "" {
+		return declaration
+	}
+	return append(yaml.MapSlice{
+		{Key: "description", Value: description},
+	}, declaration...)
+}
+
+// enumerationValues returns the items of the given enum values
+// named by their values and true if the values are either all strings
+// or all integers, otherwise returns false. Null values are skipped
+func enumerationValues(enum []interface{}) (yaml.MapSlice, bool) {
+	values := yaml.MapSlice{}
+	kind := document.NoValue
+	for _, value := range enum {
+		var valueKind document.EnumerationValueKind
+		switch value.(type) {
+		case nil:
+			continue
+		case string:
+			valueKind = document.StringValue
+		case int, int64, uint64:
+			valueKind = document.IntegerValue
+		default:
+			return nil, false
+		}
+		if kind != document.NoValue && valueKind != kind {
+			return nil, false
+		}
+		kind = valueKind
+		values = append(values, yaml.MapItem{
+			Key:   fmt.Sprint(value),
+			Value: value,
+		})
+	}
+	return values, true
+}
+
+// declare declares a type of the given name for the given schema
+func (imp *schemaImporter) declare(
+	name string,
+	schema yaml.MapSlice,
+	path []string,
+) {
+	imp.reportUnsupported(schema, path)
+
+	if reference := getString(schema, "$ref"); reference != "" {
+		imp.report(
+			appendPath(path, "$ref"),
+			"type alias '%s' of '%s' is not supported",
+			name,
+			reference,
+		)
+		return
+	}
+
+	if enum, isEnum := get(schema, "enum").([]interface{}); isEnum {
+		if values, isSupported := enumerationValues(enum); isSupported {
+			imp.enumerationTypes = append(imp.enumerationTypes, yaml.MapItem{
+				Key: name,
+				Value: withDescription(schema, yaml.MapSlice{
+					{Key: "values", Value: values},
+				}),
+			})
+			return
+		}
+		// Fall back to the type of the values
+		imp.report(
+			appendPath(path, "enum"),
+			"enumeration '%s' of values other than either strings "+
+				"or integers is not supported",
+			name,
+		)
+	}
+
+	types, _ := schemaTypes(schema)
+	if len(types) > 1 {
+		imp.report(
+			appendPath(path, "type"),
+			"union type '%s' is not supported",
+			strings.Join(types, ", "),
+		)
+		return
+	}
+	schemaType := ""
+	if len(types) == 1 {
+		schemaType = types[0]
+	}
+
+	switch schemaType {
+	case "object":
+		imp.compositeTypes = append(imp.compositeTypes, yaml.MapItem{
+			Key: name,
+			Value: withDescription(schema, yaml.MapSlice{
+				{Key: "meta", Value: imp.fields(name, schema, path)},
+			}),
+		})
+	case "array":
+		imp.report(path, "list type '%s' is not supported", name)
+	default:
+		imp.scalarTypes = append(imp.scalarTypes, yaml.MapItem{
+			Key:   name,
+			Value: withDescription(schema, scalarDeclaration(schemaType, schema)),
+		})
+	}
+}
+
+// scalarKind returns the scalar kind of the given primitive type
+// and format
+func scalarKind(schemaType, format string) document.ScalarKind {
+	switch schemaType {
+	case "string":
+		if format == "date-time" {
+			return document.TimeKind
+		}
+		return document.StringKind
+	case "integer":
+		return document.IntegerKind
+	case "number":
+		return document.NumberKind
+	case "boolean":
+		return document.BooleanKind
+	}
+	return document.AnyKind
+}
+
+// scalarConstraintKeywords maps the JSON Schema constraint keywords
+// to the TypeBook scalar constraints
+var scalarConstraintKeywords = []yaml.MapItem{
+	{Key: "pattern", Value: "pattern"},
+	{Key: "minimum", Value: "minimum"},
+	{Key: "maximum", Value: "maximum"},
+	{Key: "minLength", Value: "min length"},
+	{Key: "maxLength", Value: "max length"},
+}
+
+// hasScalarConstraints returns true if the given schema
+// constrains its values, otherwise returns false
+func hasScalarConstraints(schema yaml.MapSlice) bool {
+	for _, keyword := range scalarConstraintKeywords {
+		if get(schema, keyword.Key.(string)) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// scalarDeclaration returns the declaration of a scalar type
+// of the given primitive type
+func scalarDeclaration(schemaType string, schema yaml.MapSlice) yaml.MapSlice {
+	declaration := yaml.MapSlice{{
+		Key:   "kind",
+		Value: scalarKind(schemaType, getString(schema, "format")).String(),
+	}}
+	constraints := yaml.MapSlice{}
+	for _, keyword := range scalarConstraintKeywords {
+		if value := get(schema, keyword.Key.(string)); value != nil {
+			constraints = append(constraints, yaml.MapItem{
+				Key:   keyword.Value,
+				Value: value,
+			})
+		}
+	}
+	if len(constraints) > 0 {
+		declaration = append(declaration, yaml.MapItem{
+			Key:   "constraints",
+			Value: constraints,
+		})
+	}
+	return declaration
+}
+
+// fields returns the metadata fields of the given object schema
+func (imp *schemaImporter) fields(
+	ownerName string,
+	schema yaml.MapSlice,
+	path []string,
+) yaml.MapSlice {
+	required := make(map[string]bool)
+	if list, isList := get(schema, "required").([]interface{}); isList {
+		for _, item := range list {
+			required[fmt.Sprint(item)] = true
+		}
+	}
+
+	fields := yaml.MapSlice{}
+	for _, property := range getMapping(schema, "properties") {
+		fieldName := fmt.Sprint(property.Key)
+		propertyPath := appendPath(path, "properties", fieldName)
+		propertySchema, isSchema := property.Value.(yaml.MapSlice)
+		if !isSchema {
+			imp.report(propertyPath, "non-object schema of property '%s'", fieldName)
+			continue
+		}
+
+		fieldType, isList, nullable, isMapped := imp.fieldType(
+			ownerName+importer.TypeName(fieldName),
+			propertySchema,
+			propertyPath,
+		)
+		if !isMapped {
+			continue
+		}
+
+		dataType := document.DataType{Name: fieldType, IsList: isList}
+		field := withDescription(propertySchema, yaml.MapSlice{
+			{Key: "type", Value: dataType.String()},
+		})
+		if nullable || !required[fieldName] {
+			field = append(field, yaml.MapItem{Key: "nullable", Value: true})
+		}
+		if examples := get(propertySchema, "examples"); examples != nil {
+			field = append(field, yaml.MapItem{Key: "examples", Value: examples})
+		} else if example := get(propertySchema, "example"); example != nil {
+			field = append(field, yaml.MapItem{
+				Key:   "examples",
+				Value: []interface{}{example},
+			})
+		}
+		fields = append(fields, yaml.MapItem{Key: fieldName, Value: field})
+	}
+	return fields
+}
+
+// fieldType returns the name of the type of the given property schema,
+// whether it's a list and whether it's nullable and true if the schema
+// could be mapped, otherwise returns false. Inline enumeration, object
+// and constrained schemas are declared as types of the given name
+func (imp *schemaImporter) fieldType(
+	inlineName string,
+	schema yaml.MapSlice,
+	path []string,
+) (name string, isList bool, nullable bool, isMapped bool) {
+	types, nullable := schemaTypes(schema)
+
+	if reference := getString(schema, "$ref"); reference != "" {
+		name, isDefined := imp.names[reference]
+		if !isDefined {
+			imp.report(
+				appendPath(path, "$ref"),
+				"unresolvable reference '%s'",
+				reference,
+			)
+			return "", false, false, false
+		}
+		return name, false, nullable, name != ""
+	}
+
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		if get(schema, keyword) != nil {
+			imp.report(
+				appendPath(path, keyword),
+				"unsupported keyword '%s'",
+				keyword,
+			)
+			return "", false, false, false
+		}
+	}
+
+	if len(types) > 1 {
+		imp.report(
+			appendPath(path, "type"),
+			"union type '%s' is not supported",
+			strings.Join(types, ", "),
+		)
+		return "", false, false, false
+	}
+	schemaType := ""
+	if len(types) == 1 {
+		schemaType = types[0]
+	}
+
+	// Declare inline enumerations, objects and constrained scalars
+	declareInline := func() (string, bool, bool, bool) {
+		inlineName = imp.reserve(inlineName, path)
+		if inlineName == "" {
+			return "", false, false, false
+		}
+		imp.declare(inlineName, schema, path)
+		return inlineName, false, nullable, true
+	}
+
+	switch {
+	case get(schema, "enum") != nil:
+		return declareInline()
+
+	case schemaType == "array":
+		items, isSchema := get(schema, "items").(yaml.MapSlice)
+		if !isSchema {
+			imp.report(path, "list without item schema")
+			return "", false, false, false
+		}
+		imp.reportUnsupported(schema, path)
+		itemType, itemIsList, _, isMapped := imp.fieldType(
+			inlineName+"Item",
+			items,
+			appendPath(path, "items"),
+		)
+		if !isMapped {
+			return "", false, false, false
+		}
+		if itemIsList {
+			imp.report(path, "nested lists are not supported")
+			return "", false, false, false
+		}
+		// List items are never nullable
+		return itemType, true, nullable, true
+
+	case schemaType == "object":
+		if get(schema, "properties") == nil {
+			imp.report(path, "free-form objects are not supported")
+			return "", false, false, false
+		}
+		return declareInline()
+
+	case hasScalarConstraints(schema):
+		return declareInline()
+	}
+
+	imp.reportUnsupported(schema, path)
+	format := getString(schema, "format")
+	if schemaType == "string" && format != "" && format != "date-time" {
+		return imp.formatType(format, path), false, nullable, true
+	}
+	switch scalarKind(schemaType, format) {
+	case document.StringKind:
+		name = "String"
+	case document.TimeKind:
+		name = "Time"
+	case document.IntegerKind:
+		name = "Integer"
+	case document.NumberKind:
+		name = "Number"
+	case document.BooleanKind:
+		name = "Boolean"
+	default:
+		imp.report(path, "schema without type is not supported")
+		return "", false, false, false
+	}
+	imp.usedScalars[name] = true
+	return name, false, nullable, true
+}
+
+// formatType returns the name of the scalar type of the given
+// string format declaring it if necessary
+func (imp *schemaImporter) formatType(format string, path []string) string {
+	if name, isDeclared := imp.formats[format]; isDeclared {
+		return name
+	}
+	name := importer.TypeName(format)
+	if !importer.TypeNamePattern.MatchString(name) {
+		name = "String"
+		imp.usedScalars[name] = true
+		imp.report(path, "format '%s' is not enforced", format)
+		imp.formats[format] = name
+		return name
+	}
+	name = importer.UniqueTypeName(name, imp.declared)
+	imp.declared[name] = true
+	imp.formats[format] = name
+	imp.scalarTypes = append(imp.scalarTypes, yaml.MapItem{
+		Key: name,
+		Value: yaml.MapSlice{
+			{
+				Key:   "description",
+				Value: fmt.Sprintf("A string of the '%s' format", format),
+			},
+			{Key: "kind", Value: document.StringKind.String()},
+		},
+	})
+	return name
+}
@@ -0,0 +1,137 @@
+package jsonschema
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// TestImportDetection verifies that OpenAPI documents are recognized
+// by their schema components and that documents without any schemas
+// are reported
+func TestImportDetection(t *testing.T) {
+	schema, diagnostics, err := Import([]byte("components:\n" +
+		"  schemas:\n" +
+		"    Pet:\n" +
+		"      type: object\n" +
+		"      properties:\n" +
+		"        name:\n" +
+		"          type: string\n"))
+	if err != nil {
+		t.Fatalf("couldn't import: %s", err)
+	}
+	if len(diagnostics) > 0 {
+		t.Fatalf("unexpected diagnostics: %#v", diagnostics)
+	}
+	if !strings.Contains(string(schema), "  Pet:\n") {
+		t.Fatalf("expected the schema components to be imported:\n%s", schema)
+	}
+
+	_, diagnostics, err = Import([]byte("paths: {}\n"))
+	if err != nil {
+		t.Fatalf("couldn't import: %s", err)
+	}
+	if len(diagnostics) != 1 ||
+		diagnostics[0].Code != rend.ErrUnsupportedConstruct {
+		t.Fatalf("unexpected diagnostics: %#v", diagnostics)
+	}
+}
+
+// TestImportRenamedSchema verifies that schemas renamed to valid type
+// names are noted without reporting an unsupported construct
+func TestImportRenamedSchema(t *testing.T) {
+	schema, diagnostics, err := Import([]byte("definitions:\n" +
+		"  movie-genre:\n" +
+		"    enum: [drama, comedy]\n"))
+	if err != nil {
+		t.Fatalf("couldn't import: %s", err)
+	}
+	if len(diagnostics) != 1 ||
+		diagnostics[0].Code != rend.ErrRenamedType ||
+		diagnostics[0].Severity != rend.SeverityInfo ||
+		diagnostics[0].Message !=
+			"'movie-genre' renamed to type 'MovieGenre'" {
+		t.Fatalf("unexpected diagnostics: %#v", diagnostics)
+	}
+	if !strings.Contains(string(schema), "  MovieGenre:\n") {
+		t.Fatalf("expected the renamed type:\n%s", schema)
+	}
+}
+
+// TestImportEnumerations verifies that enumeration values keep their
+// kind and that values of other or mixed kinds are rejected
+func TestImportEnumerations(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		enum     string
+		expected map[string]document.EnumerationValue
+		rejected bool
+	}{
+		{
+			name: "strings",
+			enum: "[drama, \"1\"]",
+			expected: map[string]document.EnumerationValue{
+				"drama": {Value: "drama", Kind: document.StringValue},
+				"1":     {Value: "1", Kind: document.StringValue},
+			},
+		},
+		{
+			name: "integers",
+			enum: "[1, 2, null]",
+			expected: map[string]document.EnumerationValue{
+				"1": {Value: "1", Kind: document.IntegerValue},
+				"2": {Value: "2", Kind: document.IntegerValue},
+			},
+		},
+		{name: "numbers", enum: "[1.5, 2.5]", rejected: true},
+		{name: "mixed", enum: "[drama, 1]", rejected: true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, diagnostics, err := Import([]byte("definitions:\n" +
+				"  Genre:\n" +
+				"    enum: " + tt.enum + "\n"))
+			if err != nil {
+				t.Fatalf("couldn't import: %s", err)
+			}
+			doc, _, err := document.New(schema)
+			if err != nil {
+				t.Fatalf("couldn't parse the imported schema: %s", err)
+			}
+
+			if tt.rejected {
+				if len(diagnostics) != 1 ||
+					diagnostics[0].Code != rend.ErrUnsupportedConstruct ||
+					rend.FormatPath(diagnostics[0].Location.Path) !=
+						"definitions.Genre.enum" {
+					t.Fatalf("unexpected diagnostics: %#v", diagnostics)
+				}
+				if _, isScalar := doc.ScalarTypes["Genre"]; !isScalar {
+					t.Fatalf("expected a scalar type:\n%s", schema)
+				}
+				return
+			}
+
+			if len(diagnostics) > 0 {
+				t.Fatalf("unexpected diagnostics: %#v", diagnostics)
+			}
+			_, errs, _, err := rend.NewModel(doc, time.Time{})
+			if err != nil || len(errs) > 0 {
+				t.Fatalf("invalid imported schema: %s %#v", err, errs)
+			}
+			values := doc.EnumerationTypes["Genre"].Values
+			if len(values) != len(tt.expected) {
+				t.Fatalf("unexpected values: %#v", values)
+			}
+			for name, expected := range tt.expected {
+				value := values[name]
+				if value.Value != expected.Value ||
+					value.Kind != expected.Kind {
+					t.Fatalf("unexpected value of '%s': %#v", name, value)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,371 @@
+package sqlddl
+
+import (
+	"strings"
+
+	"github.com/go-yaml/yaml"
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/importer"
+)
+
+// relationName returns the name of the relation representing
+// the given foreign key such as "director" for "director_id"
+func relationName(fk foreignKey) string {
+	if len(fk.Columns) != 1 {
+		return fk.Table
+	}
+	name := strings.TrimSuffix(fk.Columns[0], "_id")
+	if name == "" {
+		return fk.Table
+	}
+	return name
+}
+
+// isJoinTable returns true if the given table joins two tables,
+// otherwise returns false
+func (imp *ddlImporter) isJoinTable(t *table) bool {
+	if !imp.options.CollapseJoinTables || len(t.ForeignKeys) != 2 {
+		return false
+	}
+	fkColumns := make(map[string]bool)
+	for _, fk := range t.ForeignKeys {
+		if len(fk.Columns) != 1 || imp.findTable(fk.Table) == nil {
+			return false
+		}
+		fkColumns[fk.Columns[0]] = true
+	}
+	for _, columnName := range t.PrimaryKey {
+		if !fkColumns[columnName] {
+			return false
+		}
+	}
+	return true
+}
+
+// fieldType returns the name of the type the given column type is
+// mapped to and true if it's mapped, otherwise returns false
+func (imp *ddlImporter) fieldType(
+	names map[string]string,
+	ct columnType,
+) (string, bool) {
+	if name, isDeclared := names[ct.Name]; isDeclared {
+		return name, true
+	}
+	if ct.Name == "date" {
+		imp.usedScalars[dateScalar] = true
+		return dateScalar, true
+	}
+	if builtin, isBuiltin := builtinTypes[ct.Name]; isBuiltin {
+		imp.usedScalars[builtin] = true
+		return builtin, true
+	}
+	return "", false
+}
+
+// metadata returns the metadata fields of the given columns of a table
+func (imp *ddlImporter) metadata(
+	names map[string]string,
+	t *table,
+	columns []*column,
+) yaml.MapSlice {
+	primaryKey := make(map[string]bool)
+	for _, columnName := range t.PrimaryKey {
+		primaryKey[columnName] = true
+	}
+
+	metadata := yaml.MapSlice{}
+	for _, col := range columns {
+		typeName, isMapped := imp.fieldType(names, col.Type)
+		if !isMapped {
+			imp.report(
+				col.Line,
+				"column '%s.%s' of unsupported type '%s' is skipped",
+				t.Name,
+				col.Name,
+				col.Type.Name,
+			)
+			continue
+		}
+		dataType := document.DataType{Name: typeName, IsList: col.Type.IsArray}
+		field := yaml.MapSlice{{Key: "type", Value: dataType.String()}}
+		if col.Description != "" {
+			field = append(field, yaml.MapItem{
+				Key:   "description",
+				Value: col.Description,
+			})
+		}
+		if !col.NotNull && !primaryKey[col.Name] {
+			field = append(field, yaml.MapItem{Key: "nullable", Value: true})
+		}
+		metadata = append(metadata, yaml.MapItem{Key: col.Name, Value: field})
+	}
+	return metadata
+}
+
+// withDescription prepends the given description to the given declaration
+func withDescription(
+	description string,
+	declaration yaml.MapSlice,
+) yaml.MapSlice {
+	if description == "" {
+		return declaration
+	}
+	return append(yaml.MapSlice{
+		{Key: "description", Value: description},
+	}, declaration...)
+}
+
+// scalarDeclaration returns the declaration of the scalar type
+// of the given name
+func scalarDeclaration(name string) yaml.MapSlice {
+	if name == dateScalar {
+		return yaml.MapSlice{
+			{Key: "description", Value: "An ISO 8601 calendar date"},
+			{Key: "kind", Value: document.StringKind.String()},
+			{Key: "constraints", Value: yaml.MapSlice{
+				{Key: "pattern", Value: `^\d{4}-\d{2}-\d{2}$`},
+			}},
+		}
+	}
+	scalar, _ := importer.BuiltinScalar(name)
+	return yaml.MapSlice{
+		{Key: "description", Value: scalar.Description},
+		{Key: "kind", Value: scalar.Kind.String()},
+	}
+}
+
+// document builds the schema document of the interpreted statements
+func (imp *ddlImporter) document() yaml.MapSlice {
+	// Name the enumerations, domains and tables
+	declared := make(map[string]bool)
+	for _, scalar := range importer.BuiltinScalars {
+		declared[scalar.Name] = true
+	}
+	declared[dateScalar] = true
+	reserve := func(sqlName string, line int) string {
+		name := importer.TypeName(sqlName)
+		if !importer.TypeNamePattern.MatchString(name) {
+			imp.report(line, "'%s' can't be a type name", sqlName)
+			return ""
+		}
+		unique := importer.UniqueTypeName(name, declared)
+		declared[unique] = true
+		return unique
+	}
+	typeNames := make(map[string]string)
+	for _, e := range imp.enums {
+		if name := reserve(e.Name, e.Line); name != "" {
+			typeNames[e.Name] = name
+		}
+	}
+	for _, d := range imp.domains {
+		if name := reserve(d.Name, d.Line); name != "" {
+			typeNames[d.Name] = name
+		}
+	}
+	tableNames := make(map[string]string)
+	for _, t := range imp.tables {
+		if imp.isJoinTable(t) {
+			continue
+		}
+		if name := reserve(t.Name, t.Line); name != "" {
+			tableNames[t.Name] = name
+		}
+	}
+
+	// Collect the relations of the entity types
+	relations := make(map[string]yaml.MapSlice)
+	relationColumns := make(map[string]map[string]bool)
+	addRelation := func(
+		t *table,
+		name string,
+		relation yaml.MapSlice,
+	) {
+		for _, item := range relations[t.Name] {
+			if item.Key == name {
+				name = name + "_" + t.Name
+			}
+		}
+		relations[t.Name] = append(relations[t.Name], yaml.MapItem{
+			Key:   name,
+			Value: relation,
+		})
+	}
+	for _, t := range imp.tables {
+		if imp.isJoinTable(t) {
+			imp.joinRelations(typeNames, t, tableNames, addRelation)
+			continue
+		}
+		if tableNames[t.Name] == "" {
+			continue
+		}
+		relationColumns[t.Name] = make(map[string]bool)
+		for _, fk := range t.ForeignKeys {
+			relatedType := tableNames[fk.Table]
+			if relatedType == "" {
+				imp.report(
+					fk.Line,
+					"foreign key of table '%s' references unknown table '%s'",
+					t.Name,
+					fk.Table,
+				)
+				continue
+			}
+			name := relationName(fk)
+			relationType := importer.TypeName(name)
+			if !importer.TypeNamePattern.MatchString(relationType) {
+				imp.report(fk.Line, "'%s' can't be a relation type name", name)
+				continue
+			}
+			for _, columnName := range fk.Columns {
+				relationColumns[t.Name][columnName] = true
+			}
+			addRelation(t, name, yaml.MapSlice{
+				{Key: "type", Value: relationType},
+				{Key: "direction", Value: document.OutboundRelation.String()},
+				{Key: "related type", Value: relatedType},
+			})
+		}
+	}
+
+	// Declare the types
+	scalarTypes := yaml.MapSlice{}
+	enumerationTypes := yaml.MapSlice{}
+	entityTypes := yaml.MapSlice{}
+	for _, e := range imp.enums {
+		if typeNames[e.Name] == "" {
+			continue
+		}
+		values := yaml.MapSlice{}
+		for _, value := range e.Values {
+			values = append(values, yaml.MapItem{Key: value, Value: value})
+		}
+		enumerationTypes = append(enumerationTypes, yaml.MapItem{
+			Key: typeNames[e.Name],
+			Value: withDescription(e.Description, yaml.MapSlice{
+				{Key: "values", Value: values},
+			}),
+		})
+	}
+	for _, d := range imp.domains {
+		if typeNames[d.Name] == "" {
+			continue
+		}
+		declaration := yaml.MapSlice{}
+		if baseType, isMapped := imp.fieldType(nil, d.Type); isMapped {
+			declaration = scalarDeclaration(baseType)[1:]
+		} else {
+			imp.report(d.Line, "domain '%s' of unsupported type '%s'", d.Name, d.Type.Name)
+		}
+		scalarTypes = append(scalarTypes, yaml.MapItem{
+			Key:   typeNames[d.Name],
+			Value: withDescription(d.Description, declaration),
+		})
+	}
+	for _, t := range imp.tables {
+		if tableNames[t.Name] == "" {
+			continue
+		}
+		columns := make([]*column, 0, len(t.Columns))
+		for _, col := range t.Columns {
+			if !relationColumns[t.Name][col.Name] {
+				columns = append(columns, col)
+			}
+		}
+		declaration := yaml.MapSlice{
+			{Key: "meta", Value: imp.metadata(typeNames, t, columns)},
+		}
+		if len(relations[t.Name]) > 0 {
+			declaration = append(declaration, yaml.MapItem{
+				Key:   "relations",
+				Value: relations[t.Name],
+			})
+		}
+		entityTypes = append(entityTypes, yaml.MapItem{
+			Key:   tableNames[t.Name],
+			Value: withDescription(t.Description, declaration),
+		})
+	}
+
+	// Prepend the used built-in scalar types
+	builtins := yaml.MapSlice{}
+	for _, scalar := range importer.BuiltinScalars {
+		if imp.usedScalars[scalar.Name] {
+			builtins = append(builtins, yaml.MapItem{
+				Key:   scalar.Name,
+				Value: scalarDeclaration(scalar.Name),
+			})
+		}
+	}
+	if imp.usedScalars[dateScalar] {
+		builtins = append(builtins, yaml.MapItem{
+			Key:   dateScalar,
+			Value: scalarDeclaration(dateScalar),
+		})
+	}
+	scalarTypes = append(builtins, scalarTypes...)
+
+	schema := yaml.MapSlice{}
+	for _, section := range []yaml.MapItem{
+		{Key: "scalar types", Value: scalarTypes},
+		{Key: "enumeration types", Value: enumerationTypes},
+		{Key: "entity types", Value: entityTypes},
+	} {
+		if len(section.Value.(yaml.MapSlice)) > 0 {
+			schema = append(schema, section)
+		}
+	}
+	return schema
+}
+
+// joinRelations adds the many-to-many relations
+// represented by the given join table to the joined tables
+func (imp *ddlImporter) joinRelations(
+	typeNames map[string]string,
+	join *table,
+	tableNames map[string]string,
+	addRelation func(t *table, name string, relation yaml.MapSlice),
+) {
+	from, to := join.ForeignKeys[0], join.ForeignKeys[1]
+	relationType := importer.TypeName(join.Name)
+	if !importer.TypeNamePattern.MatchString(relationType) ||
+		tableNames[from.Table] == "" ||
+		tableNames[to.Table] == "" {
+		imp.report(join.Line, "join table '%s' can't be collapsed", join.Name)
+		return
+	}
+
+	// The remaining columns become relation metadata
+	columns := make([]*column, 0, len(join.Columns))
+	for _, col := range join.Columns {
+		if col.Name != from.Columns[0] && col.Name != to.Columns[0] {
+			columns = append(columns, col)
+		}
+	}
+	metadata := imp.metadata(typeNames, join, columns)
+
+	relation := func(direction document.RelationDirection, relatedTable string) yaml.MapSlice {
+		declaration := yaml.MapSlice{
+			{Key: "type", Value: relationType},
+			{Key: "direction", Value: direction.String()},
+			{Key: "related type", Value: tableNames[relatedTable]},
+		}
+		if len(metadata) > 0 {
+			declaration = append(declaration, yaml.MapItem{
+				Key:   "meta",
+				Value: metadata,
+			})
+		}
+		return withDescription(join.Description, declaration)
+	}
+	addRelation(
+		imp.findTable(from.Table),
+		relationName(to),
+		relation(document.OutboundRelation, to.Table),
+	)
+	addRelation(
+		imp.findTable(to.Table),
+		relationName(from),
+		relation(document.InboundRelation, from.Table),
+	)
+}
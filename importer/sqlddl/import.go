@@ -0,0 +1,298 @@
+// Package sqlddl derives schema documents from the tables, enumerations
+// and domains declared by PostgreSQL data definition statements
+package sqlddl
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/go-yaml/yaml"
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// Options represents the options of the SQL DDL importer
+type Options struct {
+	// CollapseJoinTables enables collapsing join tables into
+	// many-to-many relations between the joined tables. A join table
+	// has exactly two single-column foreign keys and no primary key
+	// columns other than them. Its other columns become relation metadata
+	CollapseJoinTables bool
+}
+
+// builtinTypes maps PostgreSQL types to built-in scalar types
+var builtinTypes = map[string]string{
+	"text":                        "String",
+	"varchar":                     "String",
+	"character varying":           "String",
+	"char":                        "String",
+	"character":                   "String",
+	"bpchar":                      "String",
+	"citext":                      "String",
+	"uuid":                        "String",
+	"bytea":                       "String",
+	"inet":                        "String",
+	"cidr":                        "String",
+	"macaddr":                     "String",
+	"interval":                    "String",
+	"time":                        "String",
+	"time without time zone":      "String",
+	"time with time zone":         "String",
+	"timetz":                      "String",
+	"smallint":                    "Integer",
+	"integer":                     "Integer",
+	"int":                         "Integer",
+	"int2":                        "Integer",
+	"int4":                        "Integer",
+	"int8":                        "Integer",
+	"bigint":                      "Integer",
+	"smallserial":                 "Integer",
+	"serial":                      "Integer",
+	"bigserial":                   "Integer",
+	"serial2":                     "Integer",
+	"serial4":                     "Integer",
+	"serial8":                     "Integer",
+	"real":                        "Number",
+	"float4":                      "Number",
+	"float8":                      "Number",
+	"float":                       "Number",
+	"double precision":            "Number",
+	"numeric":                     "Number",
+	"decimal":                     "Number",
+	"money":                       "Number",
+	"boolean":                     "Boolean",
+	"bool":                        "Boolean",
+	"timestamp":                   "Time",
+	"timestamptz":                 "Time",
+	"timestamp without time zone": "Time",
+	"timestamp with time zone":    "Time",
+}
+
+// dateScalar is the name of the scalar type "date" columns are mapped to
+const dateScalar = "Date"
+
+// ddlImporter represents the state of an import
+type ddlImporter struct {
+	options     Options
+	tables      []*table
+	enums       []*enum
+	domains     []*domain
+	usedScalars map[string]bool
+	diagnostics rend.ModelErrors
+}
+
+// report reports an unsupported construct at the given line
+func (imp *ddlImporter) report(line int, format string, args ...interface{}) {
+	imp.diagnostics.AddWarnUnsupportedConstruct(
+		fmt.Sprintf(format, args...),
+		rend.Location{Description: fmt.Sprintf("line %d", line)},
+	)
+}
+
+// ImportFile reads the DDL statements of the SQL file located
+// at the given path. See Import
+func ImportFile(path string, options Options) (
+	schema []byte,
+	diagnostics rend.ModelErrors,
+	err error,
+) {
+	buf, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't read file: %s", err)
+	}
+	return Import(buf, options)
+}
+
+// Import reads the given PostgreSQL DDL statements and returns a TypeBook
+// schema document derived from the declared tables, enumerations and
+// domains and diagnostics about the constructs that have no TypeBook
+// equivalent
+func Import(buf []byte, options Options) (
+	schema []byte,
+	diagnostics rend.ModelErrors,
+	err error,
+) {
+	statements, err := tokenize(string(buf))
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't parse SQL: %s", err)
+	}
+
+	imp := &ddlImporter{
+		options:     options,
+		usedScalars: make(map[string]bool),
+	}
+	for _, statement := range statements {
+		imp.statement(&parser{tokens: statement})
+	}
+
+	schema, err = yaml.Marshal(imp.document())
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't encode schema: %s", err)
+	}
+
+	// Make sure the schema is readable
+	if _, _, err := document.New(schema); err != nil {
+		return nil, nil, fmt.Errorf("generated an unreadable schema: %s", err)
+	}
+	return schema, imp.diagnostics, nil
+}
+
+// findTable returns the table of the given name or nil if there's none
+func (imp *ddlImporter) findTable(name string) *table {
+	for _, t := range imp.tables {
+		if t.Name == name {
+			return t
+		}
+	}
+	return nil
+}
+
+// statement interprets a single statement
+func (imp *ddlImporter) statement(p *parser) {
+	line := p.peek().Line
+	switch {
+	case p.accept("create"):
+		p.accept("or", "replace")
+		for _, modifier := range []string{
+			"global",
+			"local",
+			"temp",
+			"temporary",
+			"unlogged",
+		} {
+			p.accept(modifier)
+		}
+		switch {
+		case p.accept("table"):
+			imp.createTable(p, line)
+		case p.accept("type"):
+			imp.createType(p, line)
+		case p.accept("domain"):
+			imp.createDomain(p, line)
+		case p.accept("view"), p.accept("materialized", "view"):
+			imp.report(line, "views are not supported")
+		}
+	case p.accept("alter", "table"):
+		imp.alterTable(p, line)
+	case p.accept("comment", "on"):
+		imp.comment(p, line)
+	}
+}
+
+// createTable interprets a CREATE TABLE statement
+func (imp *ddlImporter) createTable(p *parser, line int) {
+	p.accept("if", "not", "exists")
+	t := &table{Name: p.name(), Line: line}
+	if !p.accept("(") {
+		imp.report(line, "table '%s' without column definitions", t.Name)
+		return
+	}
+	for !p.done() && !p.accept(")") {
+		if p.accept(",") {
+			continue
+		}
+		if p.accept("like") {
+			imp.report(line, "LIKE clause of table '%s' is not supported", t.Name)
+			p.skipElement()
+			continue
+		}
+		if !p.tableConstraint(t) {
+			p.columnDefinition(t)
+		}
+	}
+	if p.accept("inherits") {
+		imp.report(line, "inheritance of table '%s' is not supported", t.Name)
+	}
+	imp.tables = append(imp.tables, t)
+}
+
+// createType interprets a CREATE TYPE statement
+func (imp *ddlImporter) createType(p *parser, line int) {
+	name := p.name()
+	if !p.accept("as", "enum") {
+		imp.report(line, "type '%s' is not an enumeration", name)
+		return
+	}
+	e := &enum{Name: name, Line: line}
+	p.accept("(")
+	for !p.done() && !p.accept(")") {
+		if t := p.next(); t.Kind == stringLiteral {
+			e.Values = append(e.Values, t.Text)
+		}
+	}
+	imp.enums = append(imp.enums, e)
+}
+
+// createDomain interprets a CREATE DOMAIN statement
+func (imp *ddlImporter) createDomain(p *parser, line int) {
+	name := p.name()
+	p.accept("as")
+	imp.domains = append(imp.domains, &domain{
+		Name: name,
+		Type: p.columnType(),
+		Line: line,
+	})
+	if !p.done() {
+		imp.report(line, "constraints of domain '%s' are not enforced", name)
+	}
+}
+
+// alterTable interprets the foreign and primary key constraints
+// added by an ALTER TABLE statement
+func (imp *ddlImporter) alterTable(p *parser, line int) {
+	p.accept("if", "exists")
+	p.accept("only")
+	t := imp.findTable(p.name())
+	if t == nil {
+		return
+	}
+	for !p.done() {
+		if p.accept("add") && p.tableConstraint(t) {
+			continue
+		}
+		p.next()
+	}
+}
+
+// comment interprets a COMMENT ON statement
+func (imp *ddlImporter) comment(p *parser, line int) {
+	setComment := func(target *string) {
+		if p.accept("is") {
+			if t := p.next(); t.Kind == stringLiteral {
+				*target = t.Text
+			}
+		}
+	}
+	switch {
+	case p.accept("table"):
+		if t := imp.findTable(p.name()); t != nil {
+			setComment(&t.Description)
+		}
+	case p.accept("column"):
+		parts := p.qualifiedName()
+		if len(parts) < 2 {
+			return
+		}
+		t := imp.findTable(parts[len(parts)-2])
+		if t == nil {
+			return
+		}
+		if col := t.column(parts[len(parts)-1]); col != nil {
+			setComment(&col.Description)
+		}
+	case p.accept("type"):
+		name := p.name()
+		for _, e := range imp.enums {
+			if e.Name == name {
+				setComment(&e.Description)
+			}
+		}
+	case p.accept("domain"):
+		name := p.name()
+		for _, d := range imp.domains {
+			if d.Name == name {
+				setComment(&d.Description)
+			}
+		}
+	}
+}
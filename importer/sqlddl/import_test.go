@@ -0,0 +1,150 @@
+package sqlddl
+
+import (
+	"testing"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// TestImport verifies that tables, columns, foreign keys and enums
+// are imported as their TypeBook equivalents
+func TestImport(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		sql     string
+		options Options
+		verify  func(t *testing.T, doc *document.Document)
+	}{
+		{
+			name: "quoted identifiers",
+			sql: `CREATE TABLE "Movie Star" (` +
+				`"id" SERIAL PRIMARY KEY, "full name" TEXT NOT NULL);`,
+			verify: func(t *testing.T, doc *document.Document) {
+				field, isDeclared := doc.EntityTypes["MovieStar"].
+					Metadata["full name"]
+				if !isDeclared || field.Type.Name != "String" {
+					t.Fatalf("unexpected field: %#v", field)
+				}
+			},
+		},
+		{
+			name: "inline foreign key",
+			sql: "CREATE TABLE person (id SERIAL PRIMARY KEY);\n" +
+				"CREATE TABLE movie (id SERIAL PRIMARY KEY, " +
+				"director_id INTEGER REFERENCES person(id));",
+			verify: func(t *testing.T, doc *document.Document) {
+				movie := doc.EntityTypes["Movie"]
+				relation := movie.Relations["director"]
+				if relation.Type != "Director" ||
+					relation.RelatedType != "Person" ||
+					relation.Direction != document.OutboundRelation {
+					t.Fatalf("unexpected relation: %#v", relation)
+				}
+				if _, isField := movie.Metadata["director_id"]; isField {
+					t.Fatalf("expected the foreign key not to be a field")
+				}
+			},
+		},
+		{
+			name: "table-level foreign key",
+			sql: "CREATE TABLE movie (id SERIAL PRIMARY KEY);\n" +
+				"CREATE TABLE review (id SERIAL PRIMARY KEY, " +
+				"movie_id INTEGER, " +
+				"FOREIGN KEY (movie_id) REFERENCES movie (id));",
+			verify: func(t *testing.T, doc *document.Document) {
+				relation := doc.EntityTypes["Review"].Relations["movie"]
+				if relation.RelatedType != "Movie" {
+					t.Fatalf("unexpected relation: %#v", relation)
+				}
+			},
+		},
+		{
+			name: "not null and defaults",
+			sql: "CREATE TABLE movie (id SERIAL PRIMARY KEY, " +
+				"title TEXT NOT NULL, " +
+				"rating NUMERIC DEFAULT 0, " +
+				"year INTEGER NOT NULL DEFAULT 2000);",
+			verify: func(t *testing.T, doc *document.Document) {
+				fields := doc.EntityTypes["Movie"].Metadata
+				for name, nullable := range map[string]bool{
+					"id":     false,
+					"title":  false,
+					"rating": true,
+					"year":   false,
+				} {
+					if field := fields[name]; field.Nullable != nullable {
+						t.Fatalf(
+							"expected '%s' to be nullable: %t, got %#v",
+							name,
+							nullable,
+							field,
+						)
+					}
+				}
+			},
+		},
+		{
+			name: "enums",
+			sql: "CREATE TYPE mood AS ENUM ('happy', 'sad');\n" +
+				"CREATE TABLE person (id SERIAL PRIMARY KEY, mood mood);",
+			verify: func(t *testing.T, doc *document.Document) {
+				values := doc.EnumerationTypes["Mood"].Values
+				if len(values) != 2 ||
+					values["happy"].Value != "happy" ||
+					values["sad"].Value != "sad" {
+					t.Fatalf("unexpected values: %#v", values)
+				}
+				field := doc.EntityTypes["Person"].Metadata["mood"]
+				if field.Type.Name != "Mood" {
+					t.Fatalf("unexpected field: %#v", field)
+				}
+			},
+		},
+		{
+			name: "join tables",
+			sql: "CREATE TABLE person (id SERIAL PRIMARY KEY);\n" +
+				"CREATE TABLE movie (id SERIAL PRIMARY KEY);\n" +
+				"CREATE TABLE movie_actor (" +
+				"movie_id INTEGER REFERENCES movie(id), " +
+				"person_id INTEGER REFERENCES person(id), " +
+				"role TEXT, " +
+				"PRIMARY KEY (movie_id, person_id));",
+			options: Options{CollapseJoinTables: true},
+			verify: func(t *testing.T, doc *document.Document) {
+				if _, isEntity := doc.EntityTypes["MovieActor"]; isEntity {
+					t.Fatalf("expected the join table to be collapsed")
+				}
+				outbound := doc.EntityTypes["Movie"].Relations["person"]
+				inbound := doc.EntityTypes["Person"].Relations["movie"]
+				if outbound.Type != "MovieActor" ||
+					outbound.Direction != document.OutboundRelation ||
+					outbound.RelatedType != "Person" {
+					t.Fatalf("unexpected outbound relation: %#v", outbound)
+				}
+				if inbound.Type != "MovieActor" ||
+					inbound.Direction != document.InboundRelation ||
+					inbound.RelatedType != "Movie" {
+					t.Fatalf("unexpected inbound relation: %#v", inbound)
+				}
+				if _, isField := outbound.Metadata["role"]; !isField {
+					t.Fatalf("expected the remaining columns as metadata")
+				}
+			},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			schema, _, err := Import([]byte(tt.sql), tt.options)
+			if err != nil {
+				t.Fatalf("couldn't import: %s", err)
+			}
+			doc, _, err := document.New(schema)
+			if err != nil {
+				t.Fatalf("couldn't parse the imported schema: %s", err)
+			}
+			if len(doc.Problems) > 0 {
+				t.Fatalf("unexpected problems: %#v", doc.Problems)
+			}
+			tt.verify(t, doc)
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package sqlddl
+
+import (
+	"fmt"
+	"strings"
+)
+
+// tokenKind represents the kind of a token
+type tokenKind uint8
+
+const (
+	// word represents keywords and unquoted identifiers
+	word tokenKind = iota
+
+	// quotedIdentifier represents double-quoted identifiers
+	quotedIdentifier
+
+	// stringLiteral represents single-quoted string literals
+	stringLiteral
+
+	// punctuation represents any other single character
+	punctuation
+)
+
+// token represents a lexical token of an SQL statement
+type token struct {
+	Kind tokenKind
+	Text string
+
+	// Line is the 1-based line the token starts at
+	Line int
+}
+
+// is returns true if the token is the given keyword or punctuation
+// ignoring the case, otherwise returns false
+func (t token) is(text string) bool {
+	return (t.Kind == word || t.Kind == punctuation) &&
+		strings.EqualFold(t.Text, text)
+}
+
+// isWordChar returns true if the given byte can be part of a word
+func isWordChar(c byte) bool {
+	return c == '_' || c == '$' || c == '.' ||
+		c >= 'a' && c <= 'z' ||
+		c >= 'A' && c <= 'Z' ||
+		c >= '0' && c <= '9' ||
+		c >= 0x80
+}
+
+// tokenize splits the given SQL source into statements of tokens
+func tokenize(source string) ([][]token, error) {
+	statements := make([][]token, 0)
+	statement := make([]token, 0)
+	line := 1
+
+	for i := 0; i < len(source); {
+		c := source[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+
+		case strings.HasPrefix(source[i:], "--"):
+			end := strings.IndexByte(source[i:], '\n')
+			if end < 0 {
+				end = len(source) - i
+			}
+			i += end
+
+		case strings.HasPrefix(source[i:], "/*"):
+			end := strings.Index(source[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("line %d: unterminated comment", line)
+			}
+			comment := source[i : i+2+end+2]
+			line += strings.Count(comment, "\n")
+			i += len(comment)
+
+		case c == '\'' || c == '"':
+			startLine := line
+			var text strings.Builder
+			j := i + 1
+			for {
+				if j >= len(source) {
+					return nil, fmt.Errorf(
+						"line %d: unterminated quote",
+						startLine,
+					)
+				}
+				if source[j] == c {
+					// Doubled quotes escape the quote
+					if j+1 < len(source) && source[j+1] == c {
+						text.WriteByte(c)
+						j += 2
+						continue
+					}
+					break
+				}
+				if source[j] == '\n' {
+					line++
+				}
+				text.WriteByte(source[j])
+				j++
+			}
+			kind := stringLiteral
+			if c == '"' {
+				kind = quotedIdentifier
+			}
+			statement = append(statement, token{
+				Kind: kind,
+				Text: text.String(),
+				Line: startLine,
+			})
+			i = j + 1
+
+		case c == ';':
+			if len(statement) > 0 {
+				statements = append(statements, statement)
+				statement = make([]token, 0)
+			}
+			i++
+
+		case isWordChar(c):
+			j := i
+			for j < len(source) && isWordChar(source[j]) {
+				j++
+			}
+			statement = append(statement, token{
+				Kind: word,
+				Text: source[i:j],
+				Line: line,
+			})
+			i = j
+
+		default:
+			statement = append(statement, token{
+				Kind: punctuation,
+				Text: string(c),
+				Line: line,
+			})
+			i++
+		}
+	}
+	if len(statement) > 0 {
+		statements = append(statements, statement)
+	}
+	return statements, nil
+}
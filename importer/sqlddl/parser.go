@@ -0,0 +1,331 @@
+package sqlddl
+
+import (
+	"strings"
+)
+
+// columnType represents the type of a column
+type columnType struct {
+	// Name is the lower-case type name without parameters
+	// such as "character varying"
+	Name string
+
+	IsArray bool
+}
+
+// column represents a table column
+type column struct {
+	Name        string
+	Type        columnType
+	NotNull     bool
+	Description string
+	Line        int
+}
+
+// foreignKey represents a foreign key constraint
+type foreignKey struct {
+	Columns []string
+	Table   string
+	Line    int
+}
+
+// table represents a table declared by a CREATE TABLE statement
+type table struct {
+	Name        string
+	Description string
+	Columns     []*column
+	PrimaryKey  []string
+	ForeignKeys []foreignKey
+	Line        int
+}
+
+// column returns the column of the given name or nil if there's none
+func (t *table) column(name string) *column {
+	for _, col := range t.Columns {
+		if col.Name == name {
+			return col
+		}
+	}
+	return nil
+}
+
+// enum represents an enumeration declared
+// by a CREATE TYPE ... AS ENUM statement
+type enum struct {
+	Name        string
+	Values      []string
+	Description string
+	Line        int
+}
+
+// domain represents a domain declared by a CREATE DOMAIN statement
+type domain struct {
+	Name        string
+	Type        columnType
+	Description string
+	Line        int
+}
+
+// columnModifiers lists the keywords terminating column types
+var columnModifiers = map[string]bool{
+	"not":        true,
+	"null":       true,
+	"primary":    true,
+	"references": true,
+	"default":    true,
+	"unique":     true,
+	"check":      true,
+	"constraint": true,
+	"generated":  true,
+	"collate":    true,
+}
+
+// parser represents a recursive descent parser of a single statement
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+// peek returns the current token without consuming it
+func (p *parser) peek() token {
+	if p.pos >= len(p.tokens) {
+		return token{Kind: punctuation}
+	}
+	return p.tokens[p.pos]
+}
+
+// next consumes and returns the current token
+func (p *parser) next() token {
+	t := p.peek()
+	if p.pos < len(p.tokens) {
+		p.pos++
+	}
+	return t
+}
+
+// done returns true if all tokens have been consumed
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// accept consumes the given sequence of keywords and returns true
+// if the upcoming tokens match it, otherwise consumes nothing
+// and returns false
+func (p *parser) accept(keywords ...string) bool {
+	for i, keyword := range keywords {
+		if p.pos+i >= len(p.tokens) || !p.tokens[p.pos+i].is(keyword) {
+			return false
+		}
+	}
+	p.pos += len(keywords)
+	return true
+}
+
+// qualifiedName consumes a possibly schema-qualified name
+// and returns its parts. Unquoted identifiers are folded to lower case
+func (p *parser) qualifiedName() []string {
+	parts := make([]string, 0, 2)
+	first := p.next()
+	if first.Kind == word {
+		parts = append(parts, strings.Split(strings.ToLower(first.Text), ".")...)
+	} else {
+		parts = append(parts, first.Text)
+	}
+	for {
+		t := p.peek()
+		if t.Kind != word || !strings.HasPrefix(t.Text, ".") {
+			break
+		}
+		p.next()
+		if t.Text != "." {
+			parts = append(parts, strings.Split(strings.ToLower(t.Text[1:]), ".")...)
+			continue
+		}
+		part := p.next()
+		if part.Kind == word {
+			part.Text = strings.ToLower(part.Text)
+		}
+		parts = append(parts, part.Text)
+	}
+	return parts
+}
+
+// name consumes a possibly schema-qualified name
+// and returns it without the schema
+func (p *parser) name() string {
+	parts := p.qualifiedName()
+	return parts[len(parts)-1]
+}
+
+// skipGroup skips a balanced parenthesized group
+// if the current token opens one
+func (p *parser) skipGroup() {
+	if !p.peek().is("(") {
+		return
+	}
+	depth := 0
+	for !p.done() {
+		t := p.next()
+		if t.is("(") {
+			depth++
+		} else if t.is(")") {
+			depth--
+			if depth == 0 {
+				return
+			}
+		}
+	}
+}
+
+// skipElement skips tokens until the end of the current
+// table element, leaving the terminating "," or ")" unconsumed
+func (p *parser) skipElement() {
+	for !p.done() {
+		t := p.peek()
+		if t.is(",") || t.is(")") {
+			return
+		}
+		if t.is("(") {
+			p.skipGroup()
+			continue
+		}
+		p.next()
+	}
+}
+
+// nameList consumes a parenthesized list of names
+func (p *parser) nameList() []string {
+	names := make([]string, 0)
+	if !p.accept("(") {
+		return names
+	}
+	for !p.done() && !p.peek().is(")") {
+		if p.peek().is(",") {
+			p.next()
+			continue
+		}
+		names = append(names, p.name())
+	}
+	p.accept(")")
+	return names
+}
+
+// columnType consumes a column type
+func (p *parser) columnType() columnType {
+	words := make([]string, 0, 3)
+	isArray := false
+	for !p.done() {
+		t := p.peek()
+		switch {
+		case t.is("(") && len(words) > 0:
+			// Type parameters such as varchar(255)
+			p.skipGroup()
+		case t.is("["):
+			p.next()
+			p.accept("]")
+			isArray = true
+		case t.is("array"):
+			p.next()
+			isArray = true
+		case t.Kind == quotedIdentifier && len(words) < 1:
+			p.next()
+			words = append(words, t.Text)
+		case t.Kind == word && !columnModifiers[strings.ToLower(t.Text)]:
+			parts := p.qualifiedName()
+			words = append(words, strings.ToLower(parts[len(parts)-1]))
+		default:
+			return columnType{Name: strings.Join(words, " "), IsArray: isArray}
+		}
+	}
+	return columnType{Name: strings.Join(words, " "), IsArray: isArray}
+}
+
+// references consumes a REFERENCES clause and returns
+// the name of the referenced table
+func (p *parser) references() string {
+	tableName := p.name()
+	p.nameList()
+	// Skip MATCH, ON DELETE, ON UPDATE and DEFERRABLE clauses
+	for !p.done() {
+		t := p.peek()
+		if t.is(",") || t.is(")") || columnModifiers[strings.ToLower(t.Text)] {
+			break
+		}
+		p.next()
+	}
+	return tableName
+}
+
+// columnDefinition consumes a column definition of the given table
+func (p *parser) columnDefinition(t *table) {
+	nameToken := p.peek()
+	col := &column{
+		Name: p.name(),
+		Line: nameToken.Line,
+	}
+	col.Type = p.columnType()
+
+	for !p.done() && !p.peek().is(",") && !p.peek().is(")") {
+		switch {
+		case p.accept("not", "null"):
+			col.NotNull = true
+		case p.accept("null"):
+		case p.accept("primary", "key"):
+			col.NotNull = true
+			t.PrimaryKey = []string{col.Name}
+		case p.accept("references"):
+			t.ForeignKeys = append(t.ForeignKeys, foreignKey{
+				Columns: []string{col.Name},
+				Table:   p.references(),
+				Line:    col.Line,
+			})
+		case p.accept("constraint"):
+			p.name()
+		case p.accept("check"):
+			p.skipGroup()
+		default:
+			// Skip DEFAULT expressions and other clauses
+			if p.peek().is("(") {
+				p.skipGroup()
+			} else {
+				p.next()
+			}
+		}
+	}
+	t.Columns = append(t.Columns, col)
+}
+
+// tableConstraint consumes a table constraint and returns true
+// if the current element is a table constraint, otherwise returns false
+func (p *parser) tableConstraint(t *table) bool {
+	line := p.peek().Line
+	isNamed := p.accept("constraint")
+	if isNamed {
+		p.name()
+	}
+	switch {
+	case p.accept("primary", "key"):
+		t.PrimaryKey = p.nameList()
+		for _, columnName := range t.PrimaryKey {
+			if col := t.column(columnName); col != nil {
+				col.NotNull = true
+			}
+		}
+	case p.accept("foreign", "key"):
+		columns := p.nameList()
+		if p.accept("references") {
+			t.ForeignKeys = append(t.ForeignKeys, foreignKey{
+				Columns: columns,
+				Table:   p.references(),
+				Line:    line,
+			})
+		}
+	case p.peek().is("unique"), p.peek().is("check"), p.peek().is("exclude"):
+	default:
+		if !isNamed {
+			return false
+		}
+	}
+	p.skipElement()
+	return true
+}
@@ -0,0 +1,33 @@
+package instance
+
+import "github.com/romshark/TypeBook/document"
+
+// Instances maps instance identifiers to the instance field values
+type Instances map[string]document.Object
+
+// Edge represents a relation edge between two entity instances
+type Edge struct {
+	// Type is the relation type name such as "ActedIn"
+	Type string `yaml:"type"`
+
+	// From is the identifier of the source instance
+	From string `yaml:"from"`
+
+	// To is the identifier of the target instance
+	To string `yaml:"to"`
+
+	// Metadata holds the relation metadata field values
+	Metadata document.Object `yaml:"meta"`
+}
+
+// Data represents a set of entity instances and relation edges
+type Data struct {
+	// Entities maps the entity type names to their instances
+	Entities map[string]Instances `yaml:"entities"`
+
+	// Relations lists the relation edges between the entity instances
+	Relations []Edge `yaml:"relations"`
+
+	// Source maps the instances and edges to their positions in the source
+	Source *document.SourceMap `yaml:"-"`
+}
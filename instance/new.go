@@ -0,0 +1,32 @@
+package instance
+
+import (
+	"fmt"
+	"io/ioutil"
+
+	"github.com/go-yaml/yaml"
+	"github.com/romshark/TypeBook/document"
+)
+
+// NewFromFile reads instance data from a YAML or JSON file
+// located at the given path
+func NewFromFile(inputFilePath string) (*Data, error) {
+	// Read file
+	fileContents, err := ioutil.ReadFile(inputFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't read file: %s", err)
+	}
+	return New(fileContents)
+}
+
+// New reads instance data from a YAML or JSON encoded buffer
+func New(buf []byte) (*Data, error) {
+	data := &Data{}
+
+	// YAML is a superset of JSON, both are parsed the same way
+	if err := yaml.Unmarshal(document.TagNullKeys(buf), data); err != nil {
+		return nil, fmt.Errorf("couldn't parse file: %s", err)
+	}
+	data.Source = document.NewSourceMap(buf)
+	return data, nil
+}
@@ -0,0 +1,152 @@
+package instance
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// sortedKeys returns the keys of the given map in alphabetical order
+// to keep the order of reported errors deterministic
+func sortedKeys(m map[string]Instances) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// location returns the location of the value at the given path
+func location(path []string) rend.Location {
+	return rend.Location{
+		Description: rend.FormatPath(path),
+		Path:        path,
+	}
+}
+
+// Validate returns errors if the given instance data violates
+// the given document model, otherwise returns nil.
+// It verifies the field values of every entity instance and
+// ensures that the relation edges connect the right entity types
+func Validate(
+	model *rend.Document,
+	data *Data,
+) (errors rend.ModelErrors) {
+	if model == nil {
+		panic(fmt.Errorf("missing document model"))
+	}
+	if data == nil {
+		panic(fmt.Errorf("missing instance data"))
+	}
+
+	// instanceTypes maps instance identifiers to their entity types
+	instanceTypes := make(map[string]*rend.EntityType)
+
+	// Verify entity instances
+	for _, typeName := range sortedKeys(data.Entities) {
+		instances := data.Entities[typeName]
+		typePath := []string{"entities", typeName}
+
+		entityType, isEntity := model.EntityTypes[typeName]
+		if !isEntity {
+			errors.AddErrUndefinedType(
+				typeName,
+				model.SuggestType(typeName, func(t rend.AbstractType) bool {
+					_, isEntity := t.(*rend.EntityType)
+					return isEntity
+				}),
+				location(typePath),
+			)
+			continue
+		}
+
+		ids := make([]string, 0, len(instances))
+		for id := range instances {
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		for _, id := range ids {
+			path := append(typePath[:2:2], id)
+			if declaredType, isDeclared := instanceTypes[id]; isDeclared {
+				errors.AddErrDuplicateInstance(id, rend.Location{
+					Description: fmt.Sprintf(
+						"%s (already declared as %s)",
+						rend.FormatPath(path),
+						declaredType.Name(),
+					),
+					Path: path,
+				})
+				continue
+			}
+			instanceTypes[id] = entityType
+
+			// Instances without any fields are decoded as nil
+			fields := instances[id]
+			if fields == nil {
+				fields = document.Object{}
+			}
+			errors.Add(model.VerifyValue(entityType, fields, path)...)
+		}
+	}
+
+	// Verify relation edges
+	for index, edge := range data.Relations {
+		path := []string{"relations", strconv.Itoa(index)}
+
+		fromType, fromIsDeclared := instanceTypes[edge.From]
+		if !fromIsDeclared {
+			errors.AddErrInvalidRelationEdge(
+				fmt.Sprintf("undefined source instance '%s'", edge.From),
+				location(append(path[:2:2], "from")),
+			)
+		}
+		toType, toIsDeclared := instanceTypes[edge.To]
+		if !toIsDeclared {
+			errors.AddErrInvalidRelationEdge(
+				fmt.Sprintf("undefined target instance '%s'", edge.To),
+				location(append(path[:2:2], "to")),
+			)
+		}
+		if !fromIsDeclared || !toIsDeclared {
+			continue
+		}
+
+		typeName := rend.EntityRelationTypeName{
+			SourceType:   fromType.Name(),
+			RelationType: edge.Type,
+			TargetType:   toType.Name(),
+		}
+		relationType, isDefined := model.Relations[typeName.String()]
+		if !isDefined {
+			errors.AddErrInvalidRelationEdge(
+				fmt.Sprintf(
+					"relation '%s' can't connect '%s' (%s) to '%s' (%s)",
+					edge.Type,
+					edge.From,
+					fromType.Name(),
+					edge.To,
+					toType.Name(),
+				),
+				location(append(path[:2:2], "type")),
+			)
+			continue
+		}
+
+		metadata := edge.Metadata
+		if metadata == nil {
+			metadata = document.Object{}
+		}
+		errors.Add(model.VerifyValue(
+			relationType,
+			metadata,
+			append(path[:2:2], "meta"),
+		)...)
+	}
+
+	return errors
+}
@@ -0,0 +1,205 @@
+package instance
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// testSchema is the schema the instance data of the tests is validated against
+const testSchema = "title: Test\n" +
+	"scalar types:\n" +
+	"  Number:\n" +
+	"    kind: number\n" +
+	"  String:\n" +
+	"    kind: string\n" +
+	"enumeration types:\n" +
+	"  Genre:\n" +
+	"    values:\n" +
+	"      Drama: 1\n" +
+	"      Comedy:\n" +
+	"        value: 2\n" +
+	"        aliases: [Funny]\n" +
+	"composite types:\n" +
+	"  Point:\n" +
+	"    meta:\n" +
+	"      x:\n" +
+	"        type: Number\n" +
+	"      y:\n" +
+	"        type: Number\n" +
+	"    examples:\n" +
+	"      - {x: 1, y: 2}\n" +
+	"entity types:\n" +
+	"  Shape:\n" +
+	"    meta:\n" +
+	"      origin:\n" +
+	"        type: Point\n" +
+	"  Person:\n" +
+	"    meta:\n" +
+	"      name:\n" +
+	"        type: String\n" +
+	"  Movie:\n" +
+	"    meta:\n" +
+	"      title:\n" +
+	"        type: String\n" +
+	"      genre:\n" +
+	"        type: Genre\n" +
+	"      tags:\n" +
+	"        type: List<String>\n" +
+	"      rating:\n" +
+	"        type: Number\n" +
+	"        nullable: true\n" +
+	"    relations:\n" +
+	"      Actors:\n" +
+	"        type: ActedIn\n" +
+	"        related type: Person\n"
+
+// validate validates the given instance data against the given schema
+func validate(t *testing.T, schema, data string) rend.ModelErrors {
+	t.Helper()
+	doc, _, err := document.New([]byte(schema))
+	if err != nil {
+		t.Fatalf("couldn't parse schema: %s", err)
+	}
+	model, errs, _, err := rend.NewModel(doc, time.Time{})
+	if err != nil {
+		t.Fatalf("couldn't initialize model: %s", err)
+	}
+	if errs.HasErrors() {
+		t.Fatalf("unexpected schema errors: %#v", errs)
+	}
+	instances, err := New([]byte(data))
+	if err != nil {
+		t.Fatalf("couldn't parse instance data: %s", err)
+	}
+	return Validate(model, instances)
+}
+
+// TestValidateBoolLikeKeys verifies that object keys YAML 1.1 resolves
+// to booleans such as "y" are matched against the fields by their raw names
+func TestValidateBoolLikeKeys(t *testing.T) {
+	errs := validate(t, testSchema, "entities:\n"+
+		"  Shape:\n"+
+		"    square:\n"+
+		"      origin: {x: 1, y: 2}\n")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %#v", errs)
+	}
+}
+
+// TestValidate verifies that violations of the schema
+// are reported at the location of the offending value
+func TestValidate(t *testing.T) {
+	// heat is a valid instance of the entity type "Movie"
+	const heat = "    heat: {title: Heat, genre: Drama, tags: [crime]}\n"
+
+	for _, tt := range []struct {
+		name     string
+		data     string
+		expected []rend.ErrorCode
+		paths    []string
+	}{
+		{
+			name: "valid",
+			data: "entities:\n" +
+				"  Movie:\n" + heat +
+				"    airplane:\n" +
+				"      {title: Airplane!, genre: Comedy, tags: [], rating: 7.7}\n" +
+				"  Person:\n" +
+				"    pacino: {name: Al Pacino}\n" +
+				"relations:\n" +
+				"  - {type: ActedIn, from: heat, to: pacino}\n",
+		},
+		{
+			name: "nullable field",
+			data: "entities:\n" +
+				"  Movie:\n" +
+				"    heat: {title: Heat, genre: Drama, tags: [], rating: null}\n",
+		},
+		{
+			name: "non-nullable field",
+			data: "entities:\n" +
+				"  Movie:\n" +
+				"    heat: {title: null, genre: Drama, tags: []}\n",
+			expected: []rend.ErrorCode{rend.ErrMissingValue},
+			paths:    []string{"entities.Movie.heat.title"},
+		},
+		{
+			name: "list field",
+			data: "entities:\n" +
+				"  Movie:\n" +
+				"    heat: {title: Heat, genre: Drama, tags: crime}\n",
+			expected: []rend.ErrorCode{rend.ErrInvalidValue},
+			paths:    []string{"entities.Movie.heat.tags"},
+		},
+		{
+			name: "list item",
+			data: "entities:\n" +
+				"  Movie:\n" +
+				"    heat: {title: Heat, genre: Drama, tags: [crime, 1]}\n",
+			expected: []rend.ErrorCode{rend.ErrInvalidValue},
+			paths:    []string{"entities.Movie.heat.tags[1]"},
+		},
+		{
+			name: "enumeration alias",
+			data: "entities:\n" +
+				"  Movie:\n" +
+				"    airplane: {title: Airplane!, genre: Funny, tags: []}\n",
+		},
+		{
+			name: "undefined enumeration item",
+			data: "entities:\n" +
+				"  Movie:\n" +
+				"    airplane: {title: Airplane!, genre: Farce, tags: []}\n",
+			expected: []rend.ErrorCode{rend.ErrInvalidValue},
+			paths:    []string{"entities.Movie.airplane.genre"},
+		},
+		{
+			name: "duplicate instance",
+			data: "entities:\n" +
+				"  Movie:\n" + heat +
+				"  Person:\n" +
+				"    heat: {name: Heat}\n",
+			expected: []rend.ErrorCode{rend.ErrDuplicateInstance},
+			paths:    []string{"entities.Person.heat"},
+		},
+		{
+			name: "reversed edge",
+			data: "entities:\n" +
+				"  Movie:\n" + heat +
+				"  Person:\n" +
+				"    pacino: {name: Al Pacino}\n" +
+				"relations:\n" +
+				"  - {type: ActedIn, from: pacino, to: heat}\n",
+			expected: []rend.ErrorCode{rend.ErrInvalidRelationEdge},
+			paths:    []string{"relations[0].type"},
+		},
+		{
+			name: "undefined edge endpoints",
+			data: "entities:\n" +
+				"  Movie:\n" + heat +
+				"relations:\n" +
+				"  - {type: ActedIn, from: ronin, to: deniro}\n",
+			expected: []rend.ErrorCode{
+				rend.ErrInvalidRelationEdge,
+				rend.ErrInvalidRelationEdge,
+			},
+			paths: []string{"relations[0].from", "relations[0].to"},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := validate(t, testSchema, tt.data)
+			if len(errs) != len(tt.expected) {
+				t.Fatalf("expected %d errors, got %#v", len(tt.expected), errs)
+			}
+			for index, err := range errs {
+				if err.Code != tt.expected[index] ||
+					rend.FormatPath(err.Location.Path) != tt.paths[index] {
+					t.Fatalf("unexpected error: %#v", err)
+				}
+			}
+		})
+	}
+}
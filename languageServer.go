@@ -0,0 +1,22 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+
+	"github.com/romshark/TypeBook/lsp"
+)
+
+// serveLanguageServer serves the Language Server Protocol over stdio
+func serveLanguageServer(args []string) {
+	flags := flag.NewFlagSet("lsp", flag.ExitOnError)
+	flags.Parse(args)
+
+	// Log to stderr to keep stdout reserved for the protocol
+	log.SetOutput(os.Stderr)
+
+	if err := lsp.NewServer(os.Stdin, os.Stdout).Serve(); err != nil {
+		log.Fatalf("Language server failed: %s", err)
+	}
+}
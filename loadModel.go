@@ -0,0 +1,28 @@
+package main
+
+import (
+	"log"
+	"os"
+
+	"github.com/romshark/TypeBook/compiler"
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// loadModel compiles the schema document at the given path and returns
+// its model and source map. Diagnostics are written to stderr in the given
+// format keeping stdout reserved for the generated output.
+// Exits if the schema is invalid
+func loadModel(path, format string) (
+	*rend.Document,
+	*document.SourceMap,
+) {
+	result, err := compiler.New(compiler.Options{}).CompileFile(path)
+	if err != nil {
+		log.Fatalf("Couldn't compile document: %s", err)
+	}
+	if writeDiagnostics(os.Stderr, format, result.Diagnostics) {
+		os.Exit(1)
+	}
+	return result.Model, result.Source
+}
@@ -0,0 +1,71 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// conn reads and writes JSON-RPC messages
+// framed by LSP base protocol headers
+type conn struct {
+	reader *bufio.Reader
+	writer io.Writer
+}
+
+// read reads the content of the next message
+func (c *conn) read() ([]byte, error) {
+	contentLength := -1
+	for {
+		line, err := c.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			// Headers are terminated by an empty line
+			break
+		}
+		separator := strings.IndexByte(line, ':')
+		if separator < 0 {
+			return nil, fmt.Errorf("malformed header: '%s'", line)
+		}
+		name := strings.TrimSpace(line[:separator])
+		if !strings.EqualFold(name, "Content-Length") {
+			continue
+		}
+		contentLength, err = strconv.Atoi(strings.TrimSpace(line[separator+1:]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid content length: %s", err)
+		}
+	}
+	if contentLength < 0 {
+		return nil, fmt.Errorf("missing content length")
+	}
+
+	content := make([]byte, contentLength)
+	if _, err := io.ReadFull(c.reader, content); err != nil {
+		return nil, err
+	}
+	return content, nil
+}
+
+// write writes the given message
+func (c *conn) write(message interface{}) error {
+	content, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(
+		c.writer,
+		"Content-Length: %d\r\n\r\n",
+		len(content),
+	); err != nil {
+		return err
+	}
+	_, err = c.writer.Write(content)
+	return err
+}
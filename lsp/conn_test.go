@@ -0,0 +1,69 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+// newTestConn returns a connection reading the given input
+// and writing to the given buffer
+func newTestConn(input string, output *bytes.Buffer) *conn {
+	return &conn{
+		reader: bufio.NewReader(strings.NewReader(input)),
+		writer: output,
+	}
+}
+
+// TestConnRead verifies that message contents are read
+// by the byte length declared in their headers
+func TestConnRead(t *testing.T) {
+	c := newTestConn("Content-Length: 7\r\n\r\n{\"a\":1}"+
+		"content-length: 14\r\n"+
+		"Content-Type: application/vscode-jsonrpc; charset=utf-8\r\n"+
+		"\r\n"+
+		"{\"b\":\"ä😀\"}", nil)
+
+	for _, expected := range []string{"{\"a\":1}", "{\"b\":\"ä😀\"}"} {
+		content, err := c.read()
+		if err != nil {
+			t.Fatalf("couldn't read: %s", err)
+		}
+		if string(content) != expected {
+			t.Fatalf("expected %q, got %q", expected, content)
+		}
+	}
+	if _, err := c.read(); err != io.EOF {
+		t.Fatalf("expected EOF, got %v", err)
+	}
+}
+
+// TestConnReadMalformed verifies that malformed headers are rejected
+func TestConnReadMalformed(t *testing.T) {
+	for _, input := range []string{
+		"Content-Type: application/json\r\n\r\n{}",
+		"Content-Length 2\r\n\r\n{}",
+		"Content-Length: two\r\n\r\n{}",
+		"Content-Length: 10\r\n\r\n{}",
+	} {
+		if _, err := newTestConn(input, nil).read(); err == nil {
+			t.Fatalf("expected an error reading %q", input)
+		}
+	}
+}
+
+// TestConnWrite verifies that messages are framed
+// by the byte length of their contents
+func TestConnWrite(t *testing.T) {
+	var out bytes.Buffer
+	c := newTestConn("", &out)
+	if err := c.write(map[string]string{"b": "ä😀"}); err != nil {
+		t.Fatalf("couldn't write: %s", err)
+	}
+	expected := "Content-Length: 14\r\n\r\n{\"b\":\"ä😀\"}"
+	if out.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, out.String())
+	}
+}
@@ -0,0 +1,334 @@
+package lsp
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// section represents a document section declaring types
+type section struct {
+	// Key is the key of the section such as "entity types"
+	Key string
+
+	// Kind describes the kind of the declared types such as "entity type"
+	Kind string
+}
+
+// sections lists the document sections declaring types
+var sections = []section{
+	{Key: "scalar types", Kind: "scalar type"},
+	{Key: "enumeration types", Kind: "enumeration type"},
+	{Key: "composite types", Kind: "composite type"},
+	{Key: "entity types", Kind: "entity type"},
+}
+
+// declaration represents a type declared in a schema file
+type declaration struct {
+	Name        string
+	Section     section
+	Description string
+}
+
+// reference represents a reference to a type in a schema file
+type reference struct {
+	TypeName string
+
+	// Namespace is the namespace of the type declaring the reference
+	Namespace string
+
+	// Line is the zero-based line of the reference
+	Line int
+
+	// Start and End are the byte offsets of the type name in the line
+	Start int
+	End   int
+}
+
+// referencePattern matches the value of a "type" or "related type" key
+var referencePattern = regexp.MustCompile(
+	`^\s*(?:-\s+)?(?:type|related type)\s*:\s*["']?(?:List\s*<\s*)?([A-Za-z_][A-Za-z0-9_.]*)`,
+)
+
+// yamlErrorPattern matches the line numbers of YAML parser errors
+var yamlErrorPattern = regexp.MustCompile(`line (\d+): ([^\n]*)`)
+
+// file represents an opened schema file and the results of its analysis
+type file struct {
+	uri          string
+	lines        []string
+	source       *document.SourceMap
+	declarations map[string]declaration
+	model        *rend.Document
+	references   []reference
+	diagnostics  []diagnostic
+	fixes        []fix
+}
+
+// fix represents a suggested edit resolving a diagnostic
+type fix struct {
+	Title      string
+	Diagnostic diagnostic
+	Edit       textEdit
+}
+
+// isFieldType returns true if the given path leads
+// to the type of a metadata field, otherwise returns false
+func isFieldType(path []string) bool {
+	return len(path) >= 3 &&
+		path[len(path)-1] == "type" &&
+		path[len(path)-3] == "meta"
+}
+
+// isRelatedType returns true if the given path leads
+// to the related type of a relation, otherwise returns false
+func isRelatedType(path []string) bool {
+	return len(path) >= 1 && path[len(path)-1] == "related type"
+}
+
+// newFile analyzes the given schema file. The declarations of the
+// previous version of the file are kept if the new version can't be parsed
+func newFile(uri, text string, previous *file) *file {
+	f := &file{
+		uri:         uri,
+		lines:       strings.Split(text, "\n"),
+		source:      document.NewSourceMap([]byte(text)),
+		diagnostics: make([]diagnostic, 0),
+	}
+	if previous != nil {
+		f.declarations = previous.declarations
+		f.model = previous.model
+	}
+
+	// Find the type references
+	for index, line := range f.lines {
+		match := referencePattern.FindStringSubmatchIndex(line)
+		if match == nil {
+			continue
+		}
+		path, _ := f.source.PathAt(index + 1)
+		if !isFieldType(path) && !isRelatedType(path) {
+			continue
+		}
+		var namespace string
+		if len(path) > 1 {
+			namespace, _ = document.SplitTypeName(path[1])
+		}
+		f.references = append(f.references, reference{
+			TypeName:  line[match[2]:match[3]],
+			Namespace: namespace,
+			Line:      index,
+			Start:     match[2],
+			End:       match[3],
+		})
+	}
+
+	doc, _, err := document.New([]byte(text))
+	if err != nil {
+		f.addParserErrors(err.Error())
+		return f
+	}
+	f.declarations = declarationsOf(doc)
+
+	model, errs, _, err := rend.NewModel(doc, time.Time{})
+	if err != nil {
+		f.diagnostics = append(f.diagnostics, diagnostic{
+			Severity: severityError,
+			Source:   "typebook",
+			Message:  err.Error(),
+		})
+		return f
+	}
+	f.model = model
+	for _, err := range errs {
+		f.addModelError(err)
+	}
+	return f
+}
+
+// declarationsOf returns the types declared by the given document
+func declarationsOf(doc *document.Document) map[string]declaration {
+	declarations := make(map[string]declaration)
+	add := func(name string, section section, description string) {
+		declarations[name] = declaration{
+			Name:        name,
+			Section:     section,
+			Description: description,
+		}
+	}
+	for name, t := range doc.ScalarTypes {
+		add(name, sections[0], t.Description)
+	}
+	for name, t := range doc.EnumerationTypes {
+		add(name, sections[1], t.Description)
+	}
+	for name, t := range doc.CompositeTypes {
+		add(name, sections[2], t.Description)
+	}
+	for name, t := range doc.EntityTypes {
+		add(name, sections[3], t.Description)
+	}
+	if doc.Prelude {
+		for name, t := range rend.Prelude() {
+			if _, isDeclared := declarations[name]; !isDeclared {
+				add(name, sections[0], t.Description)
+			}
+		}
+	}
+	return declarations
+}
+
+// addParserErrors adds a diagnostic for every line
+// reported by the given YAML parser error message
+func (f *file) addParserErrors(message string) {
+	matches := yamlErrorPattern.FindAllStringSubmatch(message, -1)
+	if len(matches) < 1 {
+		f.diagnostics = append(f.diagnostics, diagnostic{
+			Severity: severityError,
+			Source:   "typebook",
+			Message:  message,
+		})
+		return
+	}
+	for _, match := range matches {
+		line, _ := strconv.Atoi(match[1])
+		f.diagnostics = append(f.diagnostics, diagnostic{
+			Range:    f.lineRange(line-1, 0),
+			Severity: severityError,
+			Source:   "typebook",
+			Message:  match[2],
+		})
+	}
+}
+
+// addModelError adds a diagnostic for the given model error
+func (f *file) addModelError(err rend.ModelErr) {
+	severity := severityError
+	switch err.Severity {
+	case rend.SeverityWarning:
+		severity = severityWarning
+	case rend.SeverityInfo:
+		severity = severityInformation
+	}
+	var errRange textRange
+	if position, isMapped := f.source.LookupOccurrence(
+		err.Location.Path,
+		err.Location.Occurrence,
+	); isMapped {
+		errRange = f.lineRange(position.Line-1, position.Column-1)
+	}
+	diag := diagnostic{
+		Range:    errRange,
+		Severity: severity,
+		Code:     string(err.Code),
+		Source:   "typebook",
+		Message:  err.Message + " in " + err.Location.Description,
+	}
+	f.diagnostics = append(f.diagnostics, diag)
+
+	for _, fixIt := range err.FixIts {
+		position, isMapped := f.source.LookupOccurrence(
+			fixIt.Location.Path,
+			fixIt.Location.Occurrence,
+		)
+		if !isMapped {
+			continue
+		}
+		// The original text follows the key of the location
+		line := position.Line - 1
+		text := f.line(line)
+		from := position.Column - 1
+		if from > len(text) {
+			continue
+		}
+		start := strings.Index(text[from:], fixIt.Original)
+		if start < 0 {
+			continue
+		}
+		start += from
+		f.fixes = append(f.fixes, fix{
+			Title:      fixIt.Description,
+			Diagnostic: diag,
+			Edit: textEdit{
+				Range:   f.textRange(line, start, start+len(fixIt.Original)),
+				NewText: fixIt.Replacement,
+			},
+		})
+	}
+}
+
+// codeActions returns the quick fixes of the diagnostics
+// overlapping the given range
+func (f *file) codeActions(r textRange) []codeAction {
+	actions := make([]codeAction, 0)
+	for _, fix := range f.fixes {
+		diagRange := fix.Diagnostic.Range
+		if diagRange.End.Line < r.Start.Line ||
+			diagRange.Start.Line > r.End.Line {
+			continue
+		}
+		actions = append(actions, codeAction{
+			Title:       fix.Title,
+			Kind:        "quickfix",
+			Diagnostics: []diagnostic{fix.Diagnostic},
+			IsPreferred: true,
+			Edit: workspaceEdit{
+				Changes: map[string][]textEdit{f.uri: {fix.Edit}},
+			},
+		})
+	}
+	return actions
+}
+
+// line returns the given zero-based line or an empty string
+// if the line doesn't exist
+func (f *file) line(line int) string {
+	if line < 0 || line >= len(f.lines) {
+		return ""
+	}
+	return strings.TrimRight(f.lines[line], "\r")
+}
+
+// character returns the UTF-16 offset of the given byte offset
+// in the given zero-based line
+func (f *file) character(line, offset int) int {
+	text := f.line(line)
+	if offset > len(text) {
+		offset = len(text)
+	}
+	return len(utf16.Encode([]rune(text[:offset])))
+}
+
+// offset returns the byte offset of the given UTF-16 offset
+// in the given zero-based line
+func (f *file) offset(line, character int) int {
+	text := f.line(line)
+	offset, units := 0, 0
+	for offset < len(text) && units < character {
+		r, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+		units += len(utf16.Encode([]rune{r}))
+	}
+	return offset
+}
+
+// textRange returns the range between the given byte offsets
+// in the given zero-based line
+func (f *file) textRange(line, start, end int) textRange {
+	return textRange{
+		Start: position{Line: line, Character: f.character(line, start)},
+		End:   position{Line: line, Character: f.character(line, end)},
+	}
+}
+
+// lineRange returns the range from the given byte offset
+// to the end of the given zero-based line
+func (f *file) lineRange(line, start int) textRange {
+	return f.textRange(line, start, len(f.line(line)))
+}
@@ -0,0 +1,211 @@
+package lsp
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// completionPattern matches the beginning of a line up to the cursor
+// when completing the value of a "type", "related type" or "direction" key
+var completionPattern = regexp.MustCompile(
+	`^\s*(?:-\s+)?(type|related type|direction)\s*:\s*(?:List\s*<\s*)?[A-Za-z0-9_.]*$`,
+)
+
+// resolve returns the qualified name of the type referenced
+// by the given reference
+func (f *file) resolve(ref reference) string {
+	return document.QualifyTypeName(
+		ref.Namespace,
+		ref.TypeName,
+		func(typeName string) bool {
+			_, isDeclared := f.declarations[typeName]
+			return isDeclared
+		},
+	)
+}
+
+// targetAt returns the name of the type referenced or declared
+// at the given position and the byte offsets of the name in the line
+// and true if there is any, otherwise returns false
+func (f *file) targetAt(pos position) (
+	typeName string,
+	start int,
+	end int,
+	isTarget bool,
+) {
+	offset := f.offset(pos.Line, pos.Character)
+	for _, ref := range f.references {
+		if ref.Line == pos.Line && offset >= ref.Start && offset <= ref.End {
+			return f.resolve(ref), ref.Start, ref.End, true
+		}
+	}
+
+	// Type declaration keys
+	path, _ := f.source.PathAt(pos.Line + 1)
+	if len(path) != 2 {
+		return "", 0, 0, false
+	}
+	declared, isDeclared := f.declarations[path[1]]
+	if !isDeclared || declared.Section.Key != path[0] {
+		return "", 0, 0, false
+	}
+	start = strings.Index(f.line(pos.Line), declared.Name)
+	if start < 0 || offset < start || offset > start+len(declared.Name) {
+		return "", 0, 0, false
+	}
+	return declared.Name, start, start + len(declared.Name), true
+}
+
+// declarationLocation returns the location of the declaration of the
+// given type and true if it's declared, otherwise returns false
+func (f *file) declarationLocation(typeName string) (location, bool) {
+	declared, isDeclared := f.declarations[typeName]
+	if !isDeclared {
+		return location{}, false
+	}
+	pos, isMapped := f.source.Find([]string{declared.Section.Key, typeName})
+	if !isMapped {
+		return location{}, false
+	}
+	line := pos.Line - 1
+	start := strings.Index(f.line(line), typeName)
+	if start < 0 {
+		start = pos.Column - 1
+	}
+	return location{
+		URI:   f.uri,
+		Range: f.textRange(line, start, start+len(typeName)),
+	}, true
+}
+
+// definition returns the location of the declaration
+// of the type referenced at the given position
+func (f *file) definition(pos position) []location {
+	typeName, _, _, isTarget := f.targetAt(pos)
+	if !isTarget {
+		return []location{}
+	}
+	declaration, isDeclared := f.declarationLocation(typeName)
+	if !isDeclared {
+		return []location{}
+	}
+	return []location{declaration}
+}
+
+// referencesOf returns the locations of all references to the type
+// referenced or declared at the given position
+func (f *file) referencesOf(
+	pos position,
+	includeDeclaration bool,
+) []location {
+	locations := make([]location, 0)
+	typeName, _, _, isTarget := f.targetAt(pos)
+	if !isTarget {
+		return locations
+	}
+	if includeDeclaration {
+		if declaration, isDeclared := f.declarationLocation(
+			typeName,
+		); isDeclared {
+			locations = append(locations, declaration)
+		}
+	}
+	for _, ref := range f.references {
+		if f.resolve(ref) != typeName {
+			continue
+		}
+		locations = append(locations, location{
+			URI:   f.uri,
+			Range: f.textRange(ref.Line, ref.Start, ref.End),
+		})
+	}
+	return locations
+}
+
+// hover returns the description of the type
+// referenced or declared at the given position
+func (f *file) hover(pos position) *hover {
+	typeName, start, end, isTarget := f.targetAt(pos)
+	if !isTarget {
+		return nil
+	}
+	declared, isDeclared := f.declarations[typeName]
+	if !isDeclared {
+		return nil
+	}
+	contents := "**" + declared.Name + "** (" + declared.Section.Kind + ")"
+	if declared.Description != "" {
+		contents += "\n\n" + declared.Description
+	}
+	hoverRange := f.textRange(pos.Line, start, end)
+	return &hover{
+		Contents: markupContent{Kind: "markdown", Value: contents},
+		Range:    &hoverRange,
+	}
+}
+
+// isFieldTypeName returns true if the type declared by the given name
+// can be used as the type of a metadata field, otherwise returns false
+func (f *file) isFieldTypeName(name string) bool {
+	if f.model != nil {
+		if t, isRegistered := f.model.Types[name]; isRegistered {
+			return rend.IsFieldType(t)
+		}
+	}
+	// Types that failed the verification aren't registered
+	return f.declarations[name].Section.Key != "entity types"
+}
+
+// completion returns the declared type names or relation directions
+// applicable at the given position
+func (f *file) completion(pos position) []completionItem {
+	items := make([]completionItem, 0)
+	prefix := f.line(pos.Line)[:f.offset(pos.Line, pos.Character)]
+	match := completionPattern.FindStringSubmatch(prefix)
+	if match == nil {
+		return items
+	}
+
+	switch match[1] {
+	case "direction":
+		for _, direction := range []string{"inbound", "outbound"} {
+			items = append(items, completionItem{
+				Label:  direction,
+				Kind:   completionKindEnumValue,
+				Detail: "relation direction",
+			})
+		}
+		return items
+	case "type":
+		path, _ := f.source.PathAt(pos.Line + 1)
+		if path != nil && !isFieldType(path) {
+			// Relation type names are arbitrary
+			return items
+		}
+	}
+
+	names := make([]string, 0, len(f.declarations))
+	for name, declared := range f.declarations {
+		if match[1] == "related type" && declared.Section.Key != "entity types" {
+			// Only entity types can be related
+			continue
+		}
+		if match[1] == "type" && !f.isFieldTypeName(name) {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		items = append(items, completionItem{
+			Label:  name,
+			Kind:   completionKindClass,
+			Detail: f.declarations[name].Section.Kind,
+		})
+	}
+	return items
+}
@@ -0,0 +1,200 @@
+package lsp
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testSchema is the schema file the navigation tests are run on
+const testSchema = "title: Test\n" +
+	"scalar types:\n" +
+	"  String:\n" +
+	"    kind: string\n" +
+	"composite types:\n" +
+	"  Point:\n" +
+	"    description: A point\n" +
+	"    meta:\n" +
+	"      label:\n" +
+	"        type: String\n" +
+	"entity types:\n" +
+	"  Person:\n" +
+	"    meta:\n" +
+	"      name:\n" +
+	"        type: String\n" +
+	"      origin:\n" +
+	"        type: Point\n" +
+	"    relations:\n" +
+	"      friends:\n" +
+	"        type: FriendOf\n" +
+	"        related type: Person\n"
+
+// TestCharacterOffset verifies the conversion between byte offsets
+// and UTF-16 offsets of multi-byte and surrogate pair characters
+func TestCharacterOffset(t *testing.T) {
+	f := newFile("file:///test.yml", "# ä😀x\r\n", nil)
+	for _, tt := range []struct {
+		offset    int
+		character int
+	}{
+		{offset: 0, character: 0},
+		{offset: 2, character: 2},
+		{offset: 4, character: 3},
+		{offset: 8, character: 5},
+		{offset: 9, character: 6},
+	} {
+		if character := f.character(0, tt.offset); character != tt.character {
+			t.Fatalf(
+				"expected character %d of offset %d, got %d",
+				tt.character,
+				tt.offset,
+				character,
+			)
+		}
+		if offset := f.offset(0, tt.character); offset != tt.offset {
+			t.Fatalf(
+				"expected offset %d of character %d, got %d",
+				tt.offset,
+				tt.character,
+				offset,
+			)
+		}
+	}
+
+	// Offsets beyond the end of the line are clamped
+	if character := f.character(0, 20); character != 6 {
+		t.Fatalf("expected character 6, got %d", character)
+	}
+	if offset := f.offset(0, 20); offset != 9 {
+		t.Fatalf("expected offset 9, got %d", offset)
+	}
+}
+
+// TestDefinition verifies that references resolve
+// to the declarations of the referenced types
+func TestDefinition(t *testing.T) {
+	f := newFile("file:///test.yml", testSchema, nil)
+	locations := f.definition(position{Line: 16, Character: 16})
+	expected := []location{{
+		URI: "file:///test.yml",
+		Range: textRange{
+			Start: position{Line: 5, Character: 2},
+			End:   position{Line: 5, Character: 7},
+		},
+	}}
+	if !reflect.DeepEqual(locations, expected) {
+		t.Fatalf("unexpected locations: %#v", locations)
+	}
+
+	if locations := f.definition(position{Line: 3, Character: 5}); len(
+		locations,
+	) > 0 {
+		t.Fatalf("expected no locations, got %#v", locations)
+	}
+}
+
+// TestReferences verifies that all references to the type
+// declared at the position are found
+func TestReferences(t *testing.T) {
+	f := newFile("file:///test.yml", testSchema, nil)
+	lines := func(locations []location) []int {
+		lines := make([]int, len(locations))
+		for i, l := range locations {
+			lines[i] = l.Range.Start.Line
+		}
+		return lines
+	}
+
+	locations := f.referencesOf(position{Line: 2, Character: 3}, true)
+	if l := lines(locations); !reflect.DeepEqual(l, []int{2, 9, 14}) {
+		t.Fatalf("unexpected reference lines: %v", l)
+	}
+	if locations[1].Range != (textRange{
+		Start: position{Line: 9, Character: 14},
+		End:   position{Line: 9, Character: 20},
+	}) {
+		t.Fatalf("unexpected reference range: %#v", locations[1].Range)
+	}
+
+	locations = f.referencesOf(position{Line: 20, Character: 24}, false)
+	if l := lines(locations); !reflect.DeepEqual(l, []int{20}) {
+		t.Fatalf("unexpected reference lines: %v", l)
+	}
+}
+
+// TestHover verifies that the kind and description
+// of the type at the position are shown
+func TestHover(t *testing.T) {
+	f := newFile("file:///test.yml", testSchema, nil)
+	h := f.hover(position{Line: 16, Character: 14})
+	if h == nil {
+		t.Fatalf("expected a hover")
+	}
+	if h.Contents.Value != "**Point** (composite type)\n\nA point" {
+		t.Fatalf("unexpected contents: %q", h.Contents.Value)
+	}
+	if *h.Range != (textRange{
+		Start: position{Line: 16, Character: 14},
+		End:   position{Line: 16, Character: 19},
+	}) {
+		t.Fatalf("unexpected range: %#v", h.Range)
+	}
+
+	if h := f.hover(position{Line: 0, Character: 2}); h != nil {
+		t.Fatalf("expected no hover, got %#v", h)
+	}
+}
+
+// TestCompletion verifies that only the types applicable
+// at the position are proposed
+func TestCompletion(t *testing.T) {
+	text := strings.Replace(
+		testSchema,
+		"        type: Point\n",
+		"        type: P\n",
+		1,
+	)
+	text = strings.Replace(
+		text,
+		"        related type: Person\n",
+		"        related type: \n",
+		1,
+	)
+	f := newFile("file:///test.yml", text, nil)
+	labels := func(items []completionItem) []string {
+		labels := make([]string, len(items))
+		for i, item := range items {
+			labels[i] = item.Label
+		}
+		return labels
+	}
+
+	for _, tt := range []struct {
+		name     string
+		pos      position
+		expected []string
+	}{
+		{
+			name:     "field type",
+			pos:      position{Line: 16, Character: 15},
+			expected: []string{"Point", "String"},
+		},
+		{
+			name:     "related type",
+			pos:      position{Line: 20, Character: 22},
+			expected: []string{"Person"},
+		},
+		{
+			name:     "relation type",
+			pos:      position{Line: 19, Character: 22},
+			expected: []string{},
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			items := labels(f.completion(tt.pos))
+			if !reflect.DeepEqual(items, tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, items)
+			}
+		})
+	}
+}
@@ -0,0 +1,184 @@
+package lsp
+
+import "encoding/json"
+
+// JSON-RPC error codes
+const (
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeMethodNotFound = -32601
+	codeInvalidParams  = -32602
+)
+
+// LSP diagnostic severities
+const (
+	severityError       = 1
+	severityWarning     = 2
+	severityInformation = 3
+)
+
+// LSP completion item kinds
+const (
+	completionKindClass     = 7
+	completionKindEnumValue = 20
+)
+
+// textDocumentSyncFull instructs the client
+// to always send the full text of changed documents
+const textDocumentSyncFull = 1
+
+// request represents an incoming JSON-RPC request or notification.
+// Notifications have no identifier
+type request struct {
+	ID     *json.RawMessage `json:"id"`
+	Method string           `json:"method"`
+	Params json.RawMessage  `json:"params"`
+}
+
+// response represents a successful JSON-RPC response
+type response struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  interface{}      `json:"result"`
+}
+
+// responseError represents a JSON-RPC error
+type responseError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// errorResponse represents a failed JSON-RPC response
+type errorResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Error   responseError    `json:"error"`
+}
+
+// notification represents an outgoing JSON-RPC notification
+type notification struct {
+	JSONRPC string      `json:"jsonrpc"`
+	Method  string      `json:"method"`
+	Params  interface{} `json:"params"`
+}
+
+// position represents a zero-based position in a text document.
+// Characters are counted in UTF-16 code units
+type position struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+// textRange represents a range in a text document
+type textRange struct {
+	Start position `json:"start"`
+	End   position `json:"end"`
+}
+
+// location represents a range in a particular text document
+type location struct {
+	URI   string    `json:"uri"`
+	Range textRange `json:"range"`
+}
+
+// diagnostic represents a model error or warning
+type diagnostic struct {
+	Range    textRange `json:"range"`
+	Severity int       `json:"severity"`
+	Code     string    `json:"code,omitempty"`
+	Source   string    `json:"source"`
+	Message  string    `json:"message"`
+}
+
+// publishDiagnosticsParams represents
+// the parameters of textDocument/publishDiagnostics
+type publishDiagnosticsParams struct {
+	URI         string       `json:"uri"`
+	Diagnostics []diagnostic `json:"diagnostics"`
+}
+
+// textDocumentIdentifier identifies a text document
+type textDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+// didOpenParams represents the parameters of textDocument/didOpen
+type didOpenParams struct {
+	TextDocument struct {
+		URI  string `json:"uri"`
+		Text string `json:"text"`
+	} `json:"textDocument"`
+}
+
+// didChangeParams represents the parameters of textDocument/didChange
+type didChangeParams struct {
+	TextDocument   textDocumentIdentifier `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+// didCloseParams represents the parameters of textDocument/didClose
+type didCloseParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+}
+
+// positionParams represents the parameters of requests
+// referring to a position in a text document
+type positionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Position     position               `json:"position"`
+}
+
+// referenceParams represents the parameters of textDocument/references
+type referenceParams struct {
+	positionParams
+	Context struct {
+		IncludeDeclaration bool `json:"includeDeclaration"`
+	} `json:"context"`
+}
+
+// markupContent represents a markdown formatted string
+type markupContent struct {
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+}
+
+// hover represents the result of textDocument/hover
+type hover struct {
+	Contents markupContent `json:"contents"`
+	Range    *textRange    `json:"range,omitempty"`
+}
+
+// completionItem represents a completion proposal
+type completionItem struct {
+	Label  string `json:"label"`
+	Kind   int    `json:"kind"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// textEdit represents a replacement of a range in a text document
+type textEdit struct {
+	Range   textRange `json:"range"`
+	NewText string    `json:"newText"`
+}
+
+// workspaceEdit represents changes to text documents
+type workspaceEdit struct {
+	Changes map[string][]textEdit `json:"changes"`
+}
+
+// codeActionParams represents the parameters of textDocument/codeAction
+type codeActionParams struct {
+	TextDocument textDocumentIdentifier `json:"textDocument"`
+	Range        textRange              `json:"range"`
+}
+
+// codeAction represents a quick fix of a diagnostic
+type codeAction struct {
+	Title       string        `json:"title"`
+	Kind        string        `json:"kind"`
+	Diagnostics []diagnostic  `json:"diagnostics"`
+	IsPreferred bool          `json:"isPreferred"`
+	Edit        workspaceEdit `json:"edit"`
+}
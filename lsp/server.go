@@ -0,0 +1,247 @@
+package lsp
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Server is a Language Server Protocol server
+// providing diagnostics and navigation for schema files
+type Server struct {
+	conn     *conn
+	files    map[string]*file
+	shutdown bool
+
+	// err is the first error encountered writing a notification
+	err error
+}
+
+// NewServer creates a new language server
+// communicating through the given reader and writer
+func NewServer(reader io.Reader, writer io.Writer) *Server {
+	return &Server{
+		conn: &conn{
+			reader: bufio.NewReader(reader),
+			writer: writer,
+		},
+		files: make(map[string]*file),
+	}
+}
+
+// Serve handles incoming messages until the client
+// sends the exit notification or the connection is closed
+func (s *Server) Serve() error {
+	for {
+		content, err := s.conn.read()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("couldn't read message: %s", err)
+		}
+
+		var req request
+		if err := json.Unmarshal(content, &req); err != nil {
+			if err := s.conn.write(errorResponse{
+				JSONRPC: "2.0",
+				Error: responseError{
+					Code:    codeParseError,
+					Message: err.Error(),
+				},
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		if req.Method == "exit" {
+			return nil
+		}
+		if err := s.handle(req); err != nil {
+			return fmt.Errorf("couldn't handle %s: %s", req.Method, err)
+		}
+		if s.err != nil {
+			return fmt.Errorf("couldn't write notification: %s", s.err)
+		}
+	}
+}
+
+// handle handles the given request or notification
+func (s *Server) handle(req request) error {
+	result, resErr := s.dispatch(req)
+	if req.ID == nil {
+		// Notifications are never answered
+		return nil
+	}
+	if resErr != nil {
+		return s.conn.write(errorResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+			Error:   *resErr,
+		})
+	}
+	return s.conn.write(response{
+		JSONRPC: "2.0",
+		ID:      req.ID,
+		Result:  result,
+	})
+}
+
+// dispatch invokes the handler of the requested method
+func (s *Server) dispatch(req request) (interface{}, *responseError) {
+	decode := func(params interface{}) *responseError {
+		if err := json.Unmarshal(req.Params, params); err != nil {
+			return &responseError{
+				Code:    codeInvalidParams,
+				Message: err.Error(),
+			}
+		}
+		return nil
+	}
+
+	if s.shutdown {
+		return nil, &responseError{
+			Code:    codeInvalidRequest,
+			Message: "server is shut down",
+		}
+	}
+
+	switch req.Method {
+	case "initialize":
+		return map[string]interface{}{
+			"capabilities": map[string]interface{}{
+				"textDocumentSync":   textDocumentSyncFull,
+				"definitionProvider": true,
+				"referencesProvider": true,
+				"hoverProvider":      true,
+				"codeActionProvider": true,
+				"completionProvider": map[string]interface{}{
+					"triggerCharacters": []string{" ", "<"},
+				},
+			},
+			"serverInfo": map[string]interface{}{
+				"name": "typebook",
+			},
+		}, nil
+
+	case "initialized":
+		return nil, nil
+
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+
+	case "textDocument/didOpen":
+		var params didOpenParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		s.update(params.TextDocument.URI, params.TextDocument.Text)
+		return nil, nil
+
+	case "textDocument/didChange":
+		var params didChangeParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		changes := params.ContentChanges
+		if len(changes) > 0 {
+			// Full synchronization sends the entire text
+			s.update(params.TextDocument.URI, changes[len(changes)-1].Text)
+		}
+		return nil, nil
+
+	case "textDocument/didClose":
+		var params didCloseParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		delete(s.files, params.TextDocument.URI)
+		s.publishDiagnostics(params.TextDocument.URI, []diagnostic{})
+		return nil, nil
+
+	case "textDocument/definition":
+		var params positionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		if f := s.files[params.TextDocument.URI]; f != nil {
+			return f.definition(params.Position), nil
+		}
+		return []location{}, nil
+
+	case "textDocument/references":
+		var params referenceParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		if f := s.files[params.TextDocument.URI]; f != nil {
+			return f.referencesOf(
+				params.Position,
+				params.Context.IncludeDeclaration,
+			), nil
+		}
+		return []location{}, nil
+
+	case "textDocument/hover":
+		var params positionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		if f := s.files[params.TextDocument.URI]; f != nil {
+			if h := f.hover(params.Position); h != nil {
+				return h, nil
+			}
+		}
+		return nil, nil
+
+	case "textDocument/completion":
+		var params positionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		if f := s.files[params.TextDocument.URI]; f != nil {
+			return f.completion(params.Position), nil
+		}
+		return []completionItem{}, nil
+
+	case "textDocument/codeAction":
+		var params codeActionParams
+		if err := decode(&params); err != nil {
+			return nil, err
+		}
+		if f := s.files[params.TextDocument.URI]; f != nil {
+			return f.codeActions(params.Range), nil
+		}
+		return []codeAction{}, nil
+	}
+
+	return nil, &responseError{
+		Code:    codeMethodNotFound,
+		Message: fmt.Sprintf("unsupported method: '%s'", req.Method),
+	}
+}
+
+// update analyzes the new text of the given file
+// and publishes the resulting diagnostics
+func (s *Server) update(uri, text string) {
+	f := newFile(uri, text, s.files[uri])
+	s.files[uri] = f
+	s.publishDiagnostics(uri, f.diagnostics)
+}
+
+// publishDiagnostics sends the given diagnostics of the given file
+func (s *Server) publishDiagnostics(uri string, diagnostics []diagnostic) {
+	err := s.conn.write(notification{
+		JSONRPC: "2.0",
+		Method:  "textDocument/publishDiagnostics",
+		Params: publishDiagnosticsParams{
+			URI:         uri,
+			Diagnostics: diagnostics,
+		},
+	})
+	if err != nil && s.err == nil {
+		s.err = err
+	}
+}
@@ -0,0 +1,116 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+)
+
+// message represents any JSON-RPC message written by the server
+type message struct {
+	ID     *int            `json:"id"`
+	Method string          `json:"method"`
+	Result json.RawMessage `json:"result"`
+	Error  *responseError  `json:"error"`
+}
+
+// serve serves the given messages and returns the written messages
+func serve(t *testing.T, messages ...string) []message {
+	t.Helper()
+	var input, output bytes.Buffer
+	for _, content := range messages {
+		fmt.Fprintf(
+			&input,
+			"Content-Length: %d\r\n\r\n%s",
+			len(content),
+			content,
+		)
+	}
+	if err := NewServer(&input, &output).Serve(); err != nil {
+		t.Fatalf("couldn't serve: %s", err)
+	}
+
+	c := &conn{reader: bufio.NewReader(&output)}
+	written := make([]message, 0)
+	for {
+		content, err := c.read()
+		if err == io.EOF {
+			return written
+		}
+		if err != nil {
+			t.Fatalf("couldn't read written message: %s", err)
+		}
+		var m message
+		if err := json.Unmarshal(content, &m); err != nil {
+			t.Fatalf("couldn't decode written message: %s", err)
+		}
+		written = append(written, m)
+	}
+}
+
+// TestServerLifecycle verifies that requests are rejected after shutdown
+// and that no messages are handled after exit
+func TestServerLifecycle(t *testing.T) {
+	text, _ := json.Marshal(testSchema)
+	written := serve(t,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","method":"textDocument/didOpen","params":{`+
+			`"textDocument":{"uri":"file:///test.yml","languageId":"yaml",`+
+			`"version":1,"text":`+string(text)+`}}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"textDocument/hover","params":{`+
+			`"textDocument":{"uri":"file:///test.yml"},`+
+			`"position":{"line":16,"character":14}}}`,
+		`{"jsonrpc":"2.0","id":3,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","id":4,"method":"textDocument/hover","params":{`+
+			`"textDocument":{"uri":"file:///test.yml"},`+
+			`"position":{"line":16,"character":14}}}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+		`{"jsonrpc":"2.0","id":5,"method":"shutdown"}`,
+	)
+
+	if len(written) != 5 {
+		t.Fatalf("expected 5 messages, got %#v", written)
+	}
+	if written[0].ID == nil || *written[0].ID != 1 ||
+		!strings.Contains(string(written[0].Result), `"hoverProvider":true`) {
+		t.Fatalf("unexpected initialize response: %#v", written[0])
+	}
+	if written[1].Method != "textDocument/publishDiagnostics" {
+		t.Fatalf("expected diagnostics, got %#v", written[1])
+	}
+	if written[2].ID == nil || *written[2].ID != 2 ||
+		!strings.Contains(string(written[2].Result), "**Point**") {
+		t.Fatalf("unexpected hover response: %#v", written[2])
+	}
+	if written[3].ID == nil || *written[3].ID != 3 ||
+		written[3].Error != nil ||
+		string(written[3].Result) != "null" {
+		t.Fatalf("unexpected shutdown response: %#v", written[3])
+	}
+	if written[4].ID == nil || *written[4].ID != 4 ||
+		written[4].Error == nil ||
+		written[4].Error.Code != codeInvalidRequest {
+		t.Fatalf("expected the request to be rejected, got %#v", written[4])
+	}
+}
+
+// TestServerParseError verifies that malformed messages are answered
+// by parse errors without stopping the server
+func TestServerParseError(t *testing.T) {
+	written := serve(t,
+		`{"jsonrpc":`,
+		`{"jsonrpc":"2.0","id":1,"method":"unknown"}`,
+	)
+	if len(written) != 2 ||
+		written[0].Error == nil ||
+		written[0].Error.Code != codeParseError ||
+		written[1].Error == nil ||
+		written[1].Error.Code != codeMethodNotFound {
+		t.Fatalf("unexpected messages: %#v", written)
+	}
+}
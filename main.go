@@ -4,11 +4,14 @@ import (
 	"bytes"
 	"flag"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
 	"os"
+	"strconv"
 	"time"
 
+	"github.com/romshark/TypeBook/compiler"
 	"github.com/romshark/TypeBook/document"
 	"github.com/romshark/TypeBook/rend"
 )
@@ -23,8 +26,92 @@ var outputFilePath = flag.String(
 	"./compiled.html",
 	"HTML Output file path",
 )
+var buildTimestamp = flag.String(
+	"build-time",
+	"",
+	"Build time in Unix seconds, \"now\" or \"none\" "+
+		"(defaults to SOURCE_DATE_EPOCH if set, otherwise to \"now\")",
+)
+var format = flag.String(
+	"format",
+	"text",
+	"Diagnostics output format (text, json or sarif)",
+)
+var order = flag.String(
+	"order",
+	"declaration",
+	"Order of types, fields and values (declaration or alphabetical)",
+)
+
+// commands maps subcommand names to their implementations.
+// Running without a subcommand renders the input document
+var commands = map[string]func(args []string){
+	"validate":      validate,
+	"emit":          emit,
+	"site":          site,
+	"explain":       explain,
+	"lsp":           serveLanguageServer,
+	"import-go":     importGo,
+	"import-schema": importSchema,
+	"import-sql":    importSQL,
+	"export-sql":    exportSQL,
+	"export-proto":  exportProto,
+	"export-avro":   exportAvro,
+	"export-cypher": exportCypher,
+}
+
+// printDiagnostics prints the given diagnostics to stdout in the given
+// format and returns true if there were any errors, otherwise returns false
+func printDiagnostics(format string, diagnostics compiler.Diagnostics) bool {
+	return writeDiagnostics(os.Stdout, format, diagnostics)
+}
+
+// writeDiagnostics writes the given diagnostics in the given format
+// and returns true if there were any errors, otherwise returns false
+func writeDiagnostics(
+	w io.Writer,
+	format string,
+	diagnostics compiler.Diagnostics,
+) bool {
+	if !compiler.IsDiagnosticsFormat(format) {
+		log.Fatalf("Unsupported diagnostics format: '%s'", format)
+	}
+	if err := diagnostics.Write(w, format); err != nil {
+		log.Fatalf("Couldn't write diagnostics: %s", err)
+	}
+	return diagnostics.HasErrors()
+}
+
+// buildTime returns the build time to stamp into the rendered document
+// or a zero time if the build time is omitted
+func buildTime() (time.Time, error) {
+	timestamp := *buildTimestamp
+	if timestamp == "" {
+		// Respect the reproducible builds specification
+		// https://reproducible-builds.org/specs/source-date-epoch/
+		timestamp = os.Getenv("SOURCE_DATE_EPOCH")
+	}
+	switch timestamp {
+	case "", "now":
+		return time.Now().UTC(), nil
+	case "none":
+		return time.Time{}, nil
+	}
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid build time: '%s'", timestamp)
+	}
+	return time.Unix(seconds, 0).UTC(), nil
+}
 
 func main() {
+	if len(os.Args) > 1 {
+		if command, isCommand := commands[os.Args[1]]; isCommand {
+			command(os.Args[2:])
+			return
+		}
+	}
+
 	flag.Parse()
 
 	startProcess := time.Now()
@@ -40,27 +127,30 @@ func main() {
 		log.Fatalf("Couldn't read document: %s", err)
 	}
 
+	build, err := buildTime()
+	if err != nil {
+		log.Fatalf("Couldn't determine build time: %s", err)
+	}
+
 	// Create document model
-	documentModel, errs, _, err := rend.NewModel(document)
+	documentModel, errs, _, err := rend.NewModel(document, build)
 	if err != nil {
 		log.Fatalf("Couldn't initialize document model: %s", err)
 	}
 
 	// Print errors if any
-	if len(errs) > 0 {
-		fmt.Printf("%d errors:\n", len(errs))
-		for i, err := range errs {
-			fmt.Printf(
-				" %d (%s): %s in %s\n",
-				i,
-				err.Code,
-				err.Message,
-				err.Location,
-			)
-		}
+	if printDiagnostics(*format, compiler.NewDiagnostics(
+		*inputFilePath,
+		document.Source,
+		errs,
+	)) {
 		os.Exit(1)
 	}
 
+	if err := documentModel.Order.FromString(*order); err != nil {
+		log.Fatalf("Invalid order: %s", err)
+	}
+
 	// Render the document
 	var buf bytes.Buffer
 	renderingStats, err := renderer.Render(documentModel, &buf)
@@ -81,6 +171,11 @@ func main() {
 
 	totalProcessDur := time.Since(startProcess)
 
+	// Keep the output of machine-readable formats parsable
+	if *format != "text" {
+		return
+	}
+
 	fmt.Printf("Rendered to:         %s\n", *outputFilePath)
 	fmt.Printf("Compiling Template:  %s\n", rendererInitStats.CompileTemplateDur)
 	fmt.Printf("Parsing:             %s\n", docParsingStats.ParsingInputFileDur)
@@ -0,0 +1,375 @@
+package rend
+
+import "strings"
+
+// Explanation documents the cause and the resolution of an error code
+type Explanation struct {
+	Code     ErrorCode
+	Severity Severity
+
+	// Summary describes the error in a single line
+	Summary string
+
+	// Explanation explains the cause and how to resolve the error
+	Explanation string
+
+	// Example is a document excerpt causing the error
+	Example string
+}
+
+// catalogue documents every reported error code
+var catalogue = []Explanation{
+	{
+		Code:    ErrIllegalTypeName,
+		Summary: "A type name violates the naming rules",
+		Explanation: "By default type names must start with an upper " +
+			"case letter followed by letters and digits, namespaces " +
+			"of qualified type names such as \"billing.Invoice\" must " +
+			"consist of lower case letters and digits. The rules of " +
+			"every type category and of namespaces can be changed " +
+			"in the \"naming\" section of the document.",
+		Example: "scalar types:\n" +
+			"  user_id:\n" +
+			"    kind: string\n",
+	},
+	{
+		Code:    ErrInvalidNamingRule,
+		Summary: "A naming rule isn't a valid regular expression",
+		Explanation: "Naming rules are regular expressions in the RE2 " +
+			"syntax type names and namespaces must match. Invalid rules " +
+			"fall back to the default rules.",
+		Example: "naming:\n" +
+			"  scalar types: \"^[A-Z\"\n" +
+			"scalar types:\n" +
+			"  Text:\n" +
+			"    kind: string\n",
+	},
+	{
+		Code:    ErrUndefinedType,
+		Summary: "A referenced type isn't declared",
+		Explanation: "The type of a field and the related type of a " +
+			"relation must be declared in the document. Type names are " +
+			"case-sensitive. The most similar declared type is suggested " +
+			"if there is one.",
+		Example: "composite types:\n" +
+			"  Point:\n" +
+			"    meta:\n" +
+			"      x:\n" +
+			"        type: Numbr\n",
+	},
+	{
+		Code:    ErrTypeNameCollision,
+		Summary: "A type name is declared more than once",
+		Explanation: "Type names are shared by all type categories, " +
+			"a scalar type can't have the name of an entity type. " +
+			"Rename one of the types.",
+		Example: "scalar types:\n" +
+			"  Movie:\n" +
+			"    kind: string\n" +
+			"entity types:\n" +
+			"  Movie:\n" +
+			"    meta: {}\n",
+	},
+	{
+		Code:    ErrEntityNesting,
+		Summary: "An entity type is used as the type of a field",
+		Explanation: "Entities have an identity and can only be " +
+			"referenced by relations. Declare a relation to the entity " +
+			"type or use a composite type for embedded values.",
+		Example: "entity types:\n" +
+			"  Person:\n" +
+			"    meta: {}\n" +
+			"  Movie:\n" +
+			"    meta:\n" +
+			"      director:\n" +
+			"        type: Person\n",
+	},
+	{
+		Code:    ErrRelationTypeAsField,
+		Summary: "A relation type is used as the type of a field",
+		Explanation: "Relation types are derived from the relations " +
+			"of entity types and can't be used as field types. " +
+			"Use a composite type for the field instead.",
+		Example: "entity types:\n" +
+			"  Person:\n" +
+			"    meta:\n" +
+			"      credits:\n" +
+			"        type: Person_ActedIn_Movie\n" +
+			"    relations:\n" +
+			"      Movies:\n" +
+			"        type: ActedIn\n" +
+			"        related type: Movie\n" +
+			"  Movie:\n" +
+			"    meta: {}\n",
+	},
+	{
+		Code:    ErrInappropriateRelationTarget,
+		Summary: "A relation references a type other than an entity type",
+		Explanation: "Relations connect entities, their related type " +
+			"must be an entity type. Use a metadata field to embed " +
+			"scalar, enumeration or composite values instead.",
+		Example: "scalar types:\n" +
+			"  Score:\n" +
+			"    kind: number\n" +
+			"entity types:\n" +
+			"  Movie:\n" +
+			"    relations:\n" +
+			"      Rating:\n" +
+			"        type: RatedAs\n" +
+			"        related type: Score\n",
+	},
+	{
+		Code:    ErrInfiniteRecursion,
+		Summary: "A composite type contains itself through required fields",
+		Explanation: "Values of composite types containing themselves " +
+			"through non-nullable fields would be infinitely sized. " +
+			"Declare a field of the cycle nullable or a list.",
+		Example: "composite types:\n" +
+			"  Category:\n" +
+			"    meta:\n" +
+			"      parent:\n" +
+			"        type: Category\n",
+	},
+	{
+		Code:    ErrInconsistentEnumerationValue,
+		Summary: "The values of an enumeration type are of different kinds",
+		Explanation: "The values of the items of an enumeration type " +
+			"must either all be integers or all be strings. Quote " +
+			"numeric string values.",
+		Example: "enumeration types:\n" +
+			"  Priority:\n" +
+			"    values:\n" +
+			"      Low: 1\n" +
+			"      High: high\n",
+	},
+	{
+		Code:    ErrDuplicateEnumerationValue,
+		Summary: "A value or alias is declared by more than one item",
+		Explanation: "The values of the items of an enumeration type " +
+			"must be unique. Aliases must be unique as well and can't " +
+			"be the name of another item.",
+		Example: "enumeration types:\n" +
+			"  Priority:\n" +
+			"    values:\n" +
+			"      Low: 1\n" +
+			"      Normal: 1\n",
+	},
+	{
+		Code:    ErrInvalidScalarConstraint,
+		Summary: "The constraints of a scalar type are invalid",
+		Explanation: "Patterns must be valid regular expressions, " +
+			"textual constraints require a string kind, numeric " +
+			"constraints require a number or integer kind and minimums " +
+			"can't exceed maximums.",
+		Example: "scalar types:\n" +
+			"  Age:\n" +
+			"    kind: integer\n" +
+			"    constraints:\n" +
+			"      minimum: 150\n" +
+			"      maximum: 0\n",
+	},
+	{
+		Code:    ErrInvalidValue,
+		Summary: "A value violates its type",
+		Explanation: "Instance data and examples must match the kind " +
+			"and the constraints of scalar types, the items of " +
+			"enumeration types and the structure of complex types.",
+		Example: "entities:\n" +
+			"  Person:\n" +
+			"    keanu:\n" +
+			"      age: \"fifty\"\n",
+	},
+	{
+		Code:    ErrMissingValue,
+		Summary: "A value of a non-nullable field is missing",
+		Explanation: "Fields are required unless they're declared " +
+			"nullable. Provide a value or declare the field nullable.",
+		Example: "entities:\n" +
+			"  Person:\n" +
+			"    keanu:\n" +
+			"      name: ~\n",
+	},
+	{
+		Code:    ErrUndefinedField,
+		Summary: "A value declares a field its type doesn't define",
+		Explanation: "Objects may only contain the fields declared " +
+			"by the metadata of their type. The most similar field " +
+			"is suggested if there is one.",
+		Example: "entities:\n" +
+			"  Person:\n" +
+			"    keanu:\n" +
+			"      nmae: Keanu\n",
+	},
+	{
+		Code:    ErrDuplicateInstance,
+		Summary: "An instance identifier is declared more than once",
+		Explanation: "Instance identifiers must be unique across " +
+			"all entity types since relation edges reference them.",
+		Example: "entities:\n" +
+			"  Person:\n" +
+			"    keanu: {}\n" +
+			"  Movie:\n" +
+			"    keanu: {}\n",
+	},
+	{
+		Code:    ErrInvalidRelationEdge,
+		Summary: "A relation edge doesn't connect the right instances",
+		Explanation: "Relation edges must reference declared instances " +
+			"of the source and target types of a declared relation.",
+		Example: "relations:\n" +
+			"  - type: ActedIn\n" +
+			"    from: matrix\n" +
+			"    to: keanu\n",
+	},
+	{
+		Code:    ErrInvalidExample,
+		Summary: "An example doesn't match its type",
+		Explanation: "Examples are validated like instance data, " +
+			"they must be valid values of the type or field " +
+			"they're declared for.",
+		Example: "scalar types:\n" +
+			"  Age:\n" +
+			"    kind: integer\n" +
+			"    examples:\n" +
+			"      - \"42 years\"\n",
+	},
+	{
+		Code:    ErrInvalidReplacement,
+		Summary: "A deprecation notice references an undefined replacement",
+		Explanation: "Replacements reference either a declared type " +
+			"(\"Type\") or a member of it (\"Type.member\").",
+		Example: "scalar types:\n" +
+			"  LegacyID:\n" +
+			"    deprecated:\n" +
+			"      replacement: Identifer\n",
+	},
+	{
+		Code:     ErrDeprecatedTypeUsage,
+		Severity: SeverityWarning,
+		Summary:  "A non-deprecated field or relation uses a deprecated type",
+		Explanation: "Migrate the field or relation to the replacement " +
+			"of the deprecated type or deprecate it as well.",
+		Example: "scalar types:\n" +
+			"  LegacyID:\n" +
+			"    deprecated:\n" +
+			"      reason: Use UUIDs\n" +
+			"composite types:\n" +
+			"  Account:\n" +
+			"    meta:\n" +
+			"      id:\n" +
+			"        type: LegacyID\n",
+	},
+	{
+		Code:     ErrPreludeOverride,
+		Severity: SeverityWarning,
+		Summary:  "A declaration extends or shadows a prelude type",
+		Explanation: "Scalar types of a compatible kind extend the prelude " +
+			"type of the same name inheriting what they leave undefined. " +
+			"Other declarations replace the prelude type entirely. " +
+			"Rename the type unless that's intended.",
+		Example: "prelude: true\n" +
+			"scalar types:\n" +
+			"  UUID:\n" +
+			"    description: Identifies a record\n",
+	},
+	{
+		Code:     ErrUnsupportedConstruct,
+		Severity: SeverityWarning,
+		Summary:  "An imported construct has no equivalent",
+		Explanation: "Importers drop or approximate constructs that " +
+			"can't be expressed in a schema document such as oneOf " +
+			"or map types. Review the generated schema.",
+		Example: "components:\n" +
+			"  schemas:\n" +
+			"    Pet:\n" +
+			"      oneOf: [...]\n",
+	},
+	{
+		Code:     ErrSchemaDrift,
+		Severity: SeverityWarning,
+		Summary:  "A deployed schema deviates from the document model",
+		Explanation: "The database declares labels or relationships " +
+			"the document doesn't, or lacks ones it does. Update the " +
+			"document or migrate the database.",
+		Example: "label 'Studio' isn't declared by the schema\n",
+	},
+	{
+		Code:     ErrRenamedType,
+		Severity: SeverityInfo,
+		Summary:  "An imported definition was renamed",
+		Explanation: "Importers convert definition names that aren't " +
+			"valid type names such as \"movie-genre\" and suffix " +
+			"names that are already taken. Rename the type if the " +
+			"derived name doesn't fit.",
+		Example: "definitions:\n" +
+			"  movie-genre:\n" +
+			"    enum: [drama, comedy]\n",
+	},
+	{
+		Code:    ErrUnknownKey,
+		Summary: "A key isn't defined by the document format",
+		Explanation: "Unknown keys are most likely misspelled, " +
+			"the most similar known key is suggested if there is one.",
+		Example: "composite types:\n" +
+			"  Point:\n" +
+			"    meta:\n" +
+			"      x:\n" +
+			"        type: Number\n" +
+			"        nulable: true\n",
+	},
+	{
+		Code:    ErrDuplicateKey,
+		Summary: "A key is repeated in the same mapping",
+		Explanation: "Later declarations would silently override " +
+			"earlier ones. Remove or rename one of the keys.",
+		Example: "enumeration types:\n" +
+			"  Genre:\n" +
+			"    values:\n" +
+			"      Drama: drama\n" +
+			"      Drama: comedy\n",
+	},
+	{
+		Code:    ErrInvalidValueKind,
+		Summary: "The value of a key is of the wrong kind",
+		Explanation: "Keys expect values of a certain kind such as " +
+			"a mapping, a list or a boolean.",
+		Example: "composite types:\n" +
+			"  Point:\n" +
+			"    meta:\n" +
+			"      x:\n" +
+			"        type: Number\n" +
+			"        examples: 42\n",
+	},
+	{
+		Code:    ErrMissingKey,
+		Summary: "A required key is missing",
+		Explanation: "Fields require a type, relations require " +
+			"a relation type and a related type.",
+		Example: "entity types:\n" +
+			"  Movie:\n" +
+			"    relations:\n" +
+			"      Actors:\n" +
+			"        related type: Movie\n",
+	},
+}
+
+// Catalogue returns the explanations of all error codes
+func Catalogue() []Explanation {
+	explanations := make([]Explanation, len(catalogue))
+	copy(explanations, catalogue)
+	return explanations
+}
+
+// Explain returns the explanation of the given error code and true
+// if it's documented, otherwise returns false.
+// Codes are matched ignoring the letter case and the "Err" prefix
+func Explain(code string) (Explanation, bool) {
+	code = strings.TrimPrefix(strings.ToLower(code), "err")
+	for _, explanation := range catalogue {
+		name := strings.ToLower(string(explanation.Code))
+		if strings.TrimPrefix(name, "err") == code {
+			return explanation, true
+		}
+	}
+	return Explanation{}, false
+}
@@ -0,0 +1,42 @@
+package rend
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// TestCatalogueExamples verifies that the schema examples
+// of the error catalogue report the explained error codes
+func TestCatalogueExamples(t *testing.T) {
+	for _, explanation := range Catalogue() {
+		example := explanation.Example
+		if !strings.Contains(example, " types:\n") {
+			// Not a schema document excerpt
+			continue
+		}
+		doc, _, err := document.New([]byte(example))
+		if err != nil {
+			t.Errorf("%s: couldn't parse: %s", explanation.Code, err)
+			continue
+		}
+		_, errs, _, err := NewModel(doc, time.Time{})
+		if err != nil {
+			t.Errorf("%s: couldn't initialize model: %s", explanation.Code, err)
+			continue
+		}
+		isReported := false
+		for _, err := range errs {
+			isReported = isReported || err.Code == explanation.Code
+		}
+		if !isReported {
+			t.Errorf(
+				"%s: not reported by the example, got %v",
+				explanation.Code,
+				errs,
+			)
+		}
+	}
+}
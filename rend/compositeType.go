@@ -5,6 +5,14 @@ type CompositeType struct {
 	TypeName    string
 	Description string
 	Metadata    Metadata
+
+	// Examples lists example values of this type
+	Examples []interface{}
+
+	Deprecated *Deprecation
+
+	// Index is the declaration index of the type
+	Index int
 }
 
 // TypeCategory implements the AbstractType interface
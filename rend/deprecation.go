@@ -0,0 +1,37 @@
+package rend
+
+import (
+	"github.com/romshark/TypeBook/document"
+)
+
+// Deprecation marks a type, field, enumeration value or relation
+// as deprecated
+type Deprecation struct {
+	Reason string
+
+	// Replacement references the replacing type ("Type")
+	// or type member ("Type.member")
+	Replacement string
+
+	// ReplacementType references the type declaring the replacement,
+	// it's nil if there's no replacement
+	ReplacementType AbstractType
+
+	// RemovalVersion is the optional version the deprecated item
+	// is removed in
+	RemovalVersion string
+}
+
+// newDeprecation returns a copy of the given deprecation notice
+// or nil if there's none
+func newDeprecation(deprecation *document.Deprecation) *Deprecation {
+	if deprecation == nil {
+		return nil
+	}
+	return &Deprecation{
+		Reason:         deprecation.Reason,
+		Replacement:    deprecation.Replacement,
+		RemovalVersion: deprecation.RemovalVersion,
+		// Leave ReplacementType undefined, ref will be set automatically
+	}
+}
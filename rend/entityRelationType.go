@@ -28,6 +28,19 @@ type EntityRelationType struct {
 	TargetType      AbstractType
 	RelatedType     AbstractType
 	Direction       document.RelationDirection
+
+	// Examples lists example values of this type's metadata
+	Examples []interface{}
+
+	Deprecated *Deprecation
+
+	// RelationName is the name of the relation
+	// within the declaring entity type
+	RelationName string
+
+	// Index is the declaration index of the relation
+	// within the declaring entity type
+	Index int
 }
 
 // TypeCategory implements the AbstractType interface
@@ -49,3 +62,12 @@ func (t *EntityRelationType) Name() string {
 func (t *EntityRelationType) MetaInformation() Metadata {
 	return t.Metadata
 }
+
+// DeclaringTypeName returns the name of the entity type
+// declaring this relation
+func (t *EntityRelationType) DeclaringTypeName() string {
+	if t.Direction == document.InboundRelation {
+		return t.TargetTypeName
+	}
+	return t.SourceTypeName
+}
@@ -6,6 +6,14 @@ type EntityType struct {
 	Description string
 	Metadata    Metadata
 	Relations   Relations
+
+	// Examples lists example values of this type
+	Examples []interface{}
+
+	Deprecated *Deprecation
+
+	// Index is the declaration index of the type
+	Index int
 }
 
 // TypeCategory implements the AbstractType interface
@@ -1,7 +1,26 @@
 package rend
 
+import "github.com/romshark/TypeBook/document"
+
+// EnumerationValue represents the value of an enumeration item
+type EnumerationValue struct {
+	// Name is the name of the enumeration item
+	Name        string
+	Value       string
+	Kind        document.EnumerationValueKind
+	Description string
+
+	// Aliases lists alternative names of the item
+	Aliases []string
+
+	Deprecated *Deprecation
+
+	// Index is the declaration index of the item
+	Index int
+}
+
 // EnumerationValues maps the enumeration item to its value
-type EnumerationValues map[string]string
+type EnumerationValues map[string]EnumerationValue
 
 // EnumerationType represents a distinct enumeration type
 type EnumerationType struct {
@@ -10,6 +29,14 @@ type EnumerationType struct {
 
 	// Values maps the enumerations to their corresponding values
 	Values EnumerationValues
+
+	// Examples lists example values of this type
+	Examples []interface{}
+
+	Deprecated *Deprecation
+
+	// Index is the declaration index of the type
+	Index int
 }
 
 // TypeCategory implements the AbstractType interface
@@ -21,3 +48,30 @@ func (t *EnumerationType) TypeCategory() TypeCategory {
 func (t *EnumerationType) Name() string {
 	return t.TypeName
 }
+
+// ValueKind returns the kind of the values of the items
+// or NoValue if none of the items has a value
+func (t *EnumerationType) ValueKind() document.EnumerationValueKind {
+	for _, value := range t.Values {
+		if value.Kind != document.NoValue {
+			return value.Kind
+		}
+	}
+	return document.NoValue
+}
+
+// Item returns the item of the given name or alias
+// and true if it's declared, otherwise returns false
+func (t *EnumerationType) Item(name string) (EnumerationValue, bool) {
+	if value, isItem := t.Values[name]; isItem {
+		return value, true
+	}
+	for _, value := range t.Values {
+		for _, alias := range value.Aliases {
+			if alias == name {
+				return value, true
+			}
+		}
+	}
+	return EnumerationValue{}, false
+}
@@ -0,0 +1,41 @@
+package rend
+
+import (
+	"testing"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// TestEnumerationTypeItem verifies the shorthand and the extended form
+// of enumeration items and their lookup by name and alias
+func TestEnumerationTypeItem(t *testing.T) {
+	model, errs := newModel(t, "enumeration types:\n"+
+		"  Gender:\n"+
+		"    values:\n"+
+		"      Male: 1\n"+
+		"      Female:\n"+
+		"        value: 2\n"+
+		"        description: Female gender\n"+
+		"        aliases: [F]\n")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %#v", errs)
+	}
+	gender := model.EnumerationTypes["Gender"]
+	if gender.ValueKind() != document.IntegerValue {
+		t.Fatalf("expected integer values, got %s", gender.ValueKind())
+	}
+	for _, name := range []string{"Female", "F"} {
+		item, isItem := gender.Item(name)
+		if !isItem ||
+			item.Name != "Female" ||
+			item.Description != "Female gender" {
+			t.Fatalf("unexpected item %s: %#v", name, item)
+		}
+	}
+	if item, isItem := gender.Item("Male"); !isItem || item.Name != "Male" {
+		t.Fatalf("unexpected item Male: %#v", item)
+	}
+	if _, isItem := gender.Item("M"); isItem {
+		t.Fatalf("expected M not to be an item")
+	}
+}
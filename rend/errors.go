@@ -2,24 +2,134 @@ package rend
 
 import (
 	"fmt"
+	"strings"
+
+	"github.com/romshark/TypeBook/document"
 )
 
 // ErrorCode represents an error code
 type ErrorCode string
 
 const (
-	ErrIllegalTypeName   ErrorCode = "ErrIllegalTypeName"
-	ErrUndefinedType     ErrorCode = "ErrUndefinedType"
-	ErrTypeNameCollision ErrorCode = "ErrTypeNameCollision"
-	ErrEntityNesting     ErrorCode = "ErrEntityNesting"
-	ErrInappropriateType ErrorCode = "ErrInappropriateType"
+	ErrIllegalTypeName             ErrorCode = "ErrIllegalTypeName"
+	ErrInvalidNamingRule           ErrorCode = "ErrInvalidNamingRule"
+	ErrUndefinedType               ErrorCode = "ErrUndefinedType"
+	ErrTypeNameCollision           ErrorCode = "ErrTypeNameCollision"
+	ErrEntityNesting               ErrorCode = "ErrEntityNesting"
+	ErrRelationTypeAsField         ErrorCode = "ErrRelationTypeAsField"
+	ErrInappropriateRelationTarget ErrorCode = "ErrInappropriateRelationTarget"
+	ErrInfiniteRecursion           ErrorCode = "ErrInfiniteRecursion"
+
+	ErrInconsistentEnumerationValue ErrorCode = "ErrInconsistentEnumerationValue"
+	ErrDuplicateEnumerationValue    ErrorCode = "ErrDuplicateEnumerationValue"
+
+	ErrInvalidScalarConstraint ErrorCode = "ErrInvalidScalarConstraint"
+	ErrInvalidValue            ErrorCode = "ErrInvalidValue"
+	ErrMissingValue            ErrorCode = "ErrMissingValue"
+	ErrUndefinedField          ErrorCode = "ErrUndefinedField"
+	ErrDuplicateInstance       ErrorCode = "ErrDuplicateInstance"
+	ErrInvalidRelationEdge     ErrorCode = "ErrInvalidRelationEdge"
+	ErrInvalidExample          ErrorCode = "ErrInvalidExample"
+	ErrInvalidReplacement      ErrorCode = "ErrInvalidReplacement"
+	ErrDeprecatedTypeUsage     ErrorCode = "ErrDeprecatedTypeUsage"
+	ErrPreludeOverride         ErrorCode = "ErrPreludeOverride"
+	ErrUnsupportedConstruct    ErrorCode = "ErrUnsupportedConstruct"
+	ErrSchemaDrift             ErrorCode = "ErrSchemaDrift"
+	ErrRenamedType             ErrorCode = "ErrRenamedType"
+
+	ErrUnknownKey       ErrorCode = "ErrUnknownKey"
+	ErrDuplicateKey     ErrorCode = "ErrDuplicateKey"
+	ErrInvalidValueKind ErrorCode = "ErrInvalidValueKind"
+	ErrMissingKey       ErrorCode = "ErrMissingKey"
 )
 
+// Severity represents the severity of a model error
+type Severity uint8
+
+const (
+	// SeverityError represents errors invalidating the document model
+	SeverityError Severity = iota
+
+	// SeverityWarning represents issues
+	// that don't invalidate the document model
+	SeverityWarning
+
+	// SeverityInfo represents notes about changes
+	// that don't need any action
+	SeverityInfo
+)
+
+// String stringifies the value
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+	case SeverityWarning:
+		return "warning"
+	case SeverityInfo:
+		return "info"
+	}
+	panic(fmt.Errorf("couldn't stringify invalid Severity value: %d", s))
+}
+
 // ModelErr represents a document model error
 type ModelErr struct {
 	Code     ErrorCode
+	Severity Severity
 	Message  string
-	Location string
+	Location Location
+
+	// FixIts lists the suggested edits resolving the error
+	FixIts []FixIt
+}
+
+// FixIt represents a suggested edit replacing the original text
+// of the value at the location by the replacement
+type FixIt struct {
+	// Description describes the edit in a human readable form
+	Description string
+
+	Location    Location
+	Original    string
+	Replacement string
+}
+
+// replaceFixIts returns the fix-its replacing the given original text
+// at the given location by the suggestion if any
+func replaceFixIts(
+	original string,
+	suggestion string,
+	location Location,
+) []FixIt {
+	if suggestion == "" {
+		return nil
+	}
+	return []FixIt{{
+		Description: fmt.Sprintf(
+			"replace '%s' with '%s'",
+			original,
+			suggestion,
+		),
+		Location:    location,
+		Original:    original,
+		Replacement: suggestion,
+	}}
+}
+
+// typeSuggestionHint returns the hint suggesting the given type name
+// as a replacement of the given type name, or an empty string
+// if there's no suggestion
+func typeSuggestionHint(typeName, suggestion string) string {
+	switch {
+	case suggestion == "":
+		return ""
+	case strings.EqualFold(typeName, suggestion):
+		return fmt.Sprintf(
+			" (type names are case-sensitive, did you mean '%s'?)",
+			suggestion,
+		)
+	}
+	return fmt.Sprintf(" (did you mean '%s'?)", suggestion)
 }
 
 // Error implements the standard Go error interface
@@ -49,36 +159,119 @@ func (errs *ModelErrors) HasErrors() bool {
 	return len(*errs) > 0
 }
 
+// Deduplicate removes repeated errors of the same code and message
+// reported at the same location keeping the first one
+func (errs *ModelErrors) Deduplicate() {
+	reported := make(map[string]bool, len(*errs))
+	unique := (*errs)[:0]
+	for _, err := range *errs {
+		key := fmt.Sprintf(
+			"%s\x00%s\x00%s\x00%d",
+			err.Code,
+			err.Message,
+			strings.Join(err.Location.Path, "\x00"),
+			err.Location.Occurrence,
+		)
+		if reported[key] {
+			continue
+		}
+		reported[key] = true
+		unique = append(unique, err)
+	}
+	*errs = unique
+}
+
+// Filter returns all errors of the given severity
+func (errs *ModelErrors) Filter(severity Severity) (filtered ModelErrors) {
+	for _, err := range *errs {
+		if err.Severity == severity {
+			filtered.Add(err)
+		}
+	}
+	return filtered
+}
+
 // AddErrIllegalTypeName adds a new illegal type name error
-// indicating that a type name violates the type name rules
+// indicating that a type name violates the type name rules.
+// name is the part of the type name violating the given rule,
+// either the type name without its namespaces or one of the namespaces
 func (errs *ModelErrors) AddErrIllegalTypeName(
-	typeName,
-	errLocation string,
+	typeName string,
+	name string,
+	rule string,
+	errLocation Location,
 ) {
+	message := fmt.Sprintf(
+		"illegal type name: '%s' doesn't match '%s'",
+		typeName,
+		rule,
+	)
+	if name != typeName {
+		message = fmt.Sprintf(
+			"illegal type name: '%s', '%s' doesn't match '%s'",
+			typeName,
+			name,
+			rule,
+		)
+	}
 	errs.Add(ModelErr{
 		Code:     ErrIllegalTypeName,
-		Message:  fmt.Sprintf("illegal type name: '%s'", typeName),
+		Message:  message,
 		Location: errLocation,
 	})
 }
 
-// AddErrEntityNesting adds a entity nesting error
+// AddErrInvalidNamingRule adds an error indicating
+// that the naming rule of the given key isn't a valid regular expression
+func (errs *ModelErrors) AddErrInvalidNamingRule(
+	key string,
+	rule string,
+	err error,
+) {
+	errs.Add(ModelErr{
+		Code: ErrInvalidNamingRule,
+		Message: fmt.Sprintf(
+			"invalid naming rule '%s': %s",
+			rule,
+			err,
+		),
+		Location: Location{
+			Description: fmt.Sprintf("naming rule of %s", key),
+			Path:        []string{"naming", key},
+		},
+	})
+}
+
+// AddErrEntityNesting adds a entity nesting error.
+// The suggestion is the name of a similar type that can be used
+// as a field type instead and is omitted if empty
 func (errs *ModelErrors) AddErrEntityNesting(
-	nestedEntityTypeName,
-	containerEntityTypeName,
+	nestedEntityTypeName string,
+	containerType ComplexType,
 	fieldName string,
+	suggestion string,
 ) {
+	hint := ", entity types can only be referenced by relations"
+	if suggestion != "" {
+		hint = fmt.Sprintf(
+			", entity types can only be referenced by relations"+
+				" (did you mean '%s'?)",
+			suggestion,
+		)
+	}
 	errs.Add(ModelErr{
 		Code: ErrEntityNesting,
 		Message: fmt.Sprintf(
-			"illegal nesting of entity types ('%s' in '%s')",
+			"illegal nesting of entity types ('%s' in '%s')%s",
 			nestedEntityTypeName,
-			containerEntityTypeName,
+			containerType.Name(),
+			hint,
 		),
-		Location: fmt.Sprintf(
-			"field '%s' of type '%s'",
-			fieldName,
-			containerEntityTypeName,
+		Location: fieldLocation(containerType, fieldName),
+		FixIts: replaceFixIts(
+			nestedEntityTypeName,
+			suggestion,
+			fieldTypeLocation(containerType, fieldName),
 		),
 	})
 }
@@ -86,21 +279,17 @@ func (errs *ModelErrors) AddErrEntityNesting(
 // AddErrRelationTypeAsField adds an error
 // indicating that a relation type was used to define a field
 func (errs *ModelErrors) AddErrRelationTypeAsField(
-	relationTypeName,
-	containerTypeName,
+	relationTypeName string,
+	containerType ComplexType,
 	fieldName string,
 ) {
 	errs.Add(ModelErr{
-		Code: ErrEntityNesting,
+		Code: ErrRelationTypeAsField,
 		Message: fmt.Sprintf(
 			"illegal use of relation type '%s' for field definition",
 			relationTypeName,
 		),
-		Location: fmt.Sprintf(
-			"field '%s' of type '%s'",
-			fieldName,
-			containerTypeName,
-		),
+		Location: fieldLocation(containerType, fieldName),
 	})
 }
 
@@ -109,7 +298,7 @@ func (errs *ModelErrors) AddErrRelationTypeAsField(
 func (errs *ModelErrors) AddErrTypeNameCollision(
 	redeclaredTypeName string,
 	typeCategory string,
-	errLocation string,
+	errLocation Location,
 ) {
 	errs.Add(ModelErr{
 		Code: ErrTypeNameCollision,
@@ -123,54 +312,476 @@ func (errs *ModelErrors) AddErrTypeNameCollision(
 }
 
 // AddErrUndefinedType adds a new undefined type error
-// indicating that a referenced type is undefined
+// indicating that a referenced type is undefined.
+// The suggestion is the name of the most similar declared type
+// replacing the undefined type at the location and is omitted if empty
 func (errs *ModelErrors) AddErrUndefinedType(
 	undefinedTypeName string,
-	errLocation string,
+	suggestion string,
+	errLocation Location,
+) {
+	errs.addErrUndefinedType(
+		undefinedTypeName,
+		suggestion,
+		errLocation,
+		errLocation,
+	)
+}
+
+// addErrUndefinedType adds a new undefined type error
+// referenced by the value at the given reference location
+func (errs *ModelErrors) addErrUndefinedType(
+	undefinedTypeName string,
+	suggestion string,
+	errLocation Location,
+	referenceLocation Location,
 ) {
 	errs.Add(ModelErr{
 		Code: ErrUndefinedType,
 		Message: fmt.Sprintf(
-			"undefined type '%s'",
+			"undefined type '%s'%s",
 			undefinedTypeName,
+			typeSuggestionHint(undefinedTypeName, suggestion),
 		),
 		Location: errLocation,
+		FixIts: replaceFixIts(
+			undefinedTypeName,
+			suggestion,
+			referenceLocation,
+		),
 	})
 }
 
 // AddErrUndefinedTypeInMetaField adds a new undefined type error
-// indicating that an undefined type was used in a metadata field declaration
+// indicating that an undefined type was used in a metadata field declaration.
+// similarEntityTypeName is the name of an entity type similar to the
+// undefined type that can't be suggested since entity types can't be used
+// as field types, it's omitted if empty
 func (errs *ModelErrors) AddErrUndefinedTypeInMetaField(
 	originType ComplexType,
 	fieldName,
 	undefinedTypeName string,
+	suggestion string,
+	similarEntityTypeName string,
 ) {
-	errs.AddErrUndefinedType(undefinedTypeName, fmt.Sprintf(
-		"field '%s' of type '%s'",
-		fieldName,
-		originType.Name(),
-	))
+	errs.addErrUndefinedType(
+		undefinedTypeName,
+		suggestion,
+		fieldLocation(originType, fieldName),
+		fieldTypeLocation(originType, fieldName),
+	)
+	if suggestion == "" && similarEntityTypeName != "" {
+		err := &(*errs)[len(*errs)-1]
+		err.Message += fmt.Sprintf(
+			" ('%s' is an entity type, entity types can only be"+
+				" referenced by relations)",
+			similarEntityTypeName,
+		)
+	}
 }
 
 // AddErrUndefinedTypeAsRelation adds a new undefined type error
-// indicating that a type referenced by a relation is
+// indicating that the type related by a relation is undefined
 func (errs *ModelErrors) AddErrUndefinedTypeAsRelation(
 	originType *EntityType,
 	relationName,
 	undefinedTypeName string,
+	suggestion string,
 ) {
-	errs.AddErrUndefinedType(undefinedTypeName, fmt.Sprintf(
-		"relation '%s' of type '%s'",
-		relationName,
-		originType.Name(),
-	))
+	errs.addErrUndefinedType(
+		undefinedTypeName,
+		suggestion,
+		relationLocation(
+			originType.Name(),
+			relationName,
+			fmt.Sprintf(
+				"relation '%s' of type '%s'",
+				relationName,
+				originType.Name(),
+			),
+		),
+		relatedTypeLocation(originType.Name(), relationName),
+	)
 }
 
-// AddErrInappropriateType adds a new inappropriate type error
-// indicating that an inappropriate type was used in a certain situation
-func (errs *ModelErrors) AddErrInappropriateType(
-	undefinedTypeName string,
-	errLocation string,
+// AddErrInappropriateRelationTarget adds a new inappropriate relation
+// target error indicating that a relation references a type other than
+// an entity type. The suggestion is the name of a similar entity type
+// and is omitted if empty
+func (errs *ModelErrors) AddErrInappropriateRelationTarget(
+	inappropriateType AbstractType,
+	suggestion string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code: ErrInappropriateRelationTarget,
+		Message: fmt.Sprintf(
+			"relations can only reference entity types, '%s' is a %s type%s",
+			inappropriateType.Name(),
+			inappropriateType.TypeCategory(),
+			typeSuggestionHint(inappropriateType.Name(), suggestion),
+		),
+		Location: errLocation,
+		FixIts: replaceFixIts(
+			inappropriateType.Name(),
+			suggestion,
+			errLocation,
+		),
+	})
+}
+
+// AddErrInfiniteRecursion adds an error indicating that the given
+// composite type contains itself through the given cycle of non-nullable
+// non-list fields ("Type.field") starting with the given field
+func (errs *ModelErrors) AddErrInfiniteRecursion(
+	originType ComplexType,
+	fieldName string,
+	cycle []string,
 ) {
-	errs.AddErrUndefinedType(undefinedTypeName, errLocation)
+	errs.Add(ModelErr{
+		Code: ErrInfiniteRecursion,
+		Message: fmt.Sprintf(
+			"composite type '%s' infinitely contains itself through %s -> %s"+
+				", declare a field of the cycle nullable or a list",
+			originType.Name(),
+			strings.Join(cycle, " -> "),
+			originType.Name(),
+		),
+		Location: fieldLocation(originType, fieldName),
+	})
+}
+
+// AddErrInconsistentEnumerationValue adds an error indicating that
+// the value of the given item is of another kind than the values
+// of the preceding items of the given enumeration type
+func (errs *ModelErrors) AddErrInconsistentEnumerationValue(
+	t *EnumerationType,
+	item string,
+	kind document.EnumerationValueKind,
+	expectedKind document.EnumerationValueKind,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code: ErrInconsistentEnumerationValue,
+		Message: fmt.Sprintf(
+			"the value of item '%s' is of kind %s while the values "+
+				"of enumeration type '%s' are of kind %s",
+			item,
+			kind,
+			t.TypeName,
+			expectedKind,
+		),
+		Location: errLocation,
+	})
+}
+
+// AddErrDuplicateEnumerationValue adds an error indicating that the given
+// value or alias of the given item is already declared by another item
+// of the given enumeration type
+func (errs *ModelErrors) AddErrDuplicateEnumerationValue(
+	t *EnumerationType,
+	item string,
+	declaration string,
+	otherItem string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code: ErrDuplicateEnumerationValue,
+		Message: fmt.Sprintf(
+			"%s of item '%s' is already declared by item '%s' "+
+				"of enumeration type '%s'",
+			declaration,
+			item,
+			otherItem,
+			t.TypeName,
+		),
+		Location: errLocation,
+	})
+}
+
+// AddErrInvalidScalarConstraint adds a new invalid scalar constraint error
+// indicating that the constraints of a scalar type are contradictory
+func (errs *ModelErrors) AddErrInvalidScalarConstraint(
+	message string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrInvalidScalarConstraint,
+		Message:  message,
+		Location: errLocation,
+	})
+}
+
+// AddErrInvalidValue adds a new invalid value error
+// indicating that a value violates the type it's supposed to be of.
+// valuePath identifies the invalid value
+func (errs *ModelErrors) AddErrInvalidValue(
+	message string,
+	valuePath []string,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrInvalidValue,
+		Message:  message,
+		Location: valueLocation(valuePath),
+	})
+}
+
+// AddErrMissingValue adds a new missing value error
+// indicating that a value of a non-nullable type is missing.
+// valuePath identifies the missing value
+func (errs *ModelErrors) AddErrMissingValue(
+	message string,
+	valuePath []string,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrMissingValue,
+		Message:  message,
+		Location: valueLocation(valuePath),
+	})
+}
+
+// AddErrUndefinedField adds a new undefined field error
+// indicating that a value declares a field its type doesn't define.
+// The suggestion is the most similar field of the type
+// and is omitted if empty
+func (errs *ModelErrors) AddErrUndefinedField(
+	fieldName string,
+	t ComplexType,
+	suggestion string,
+	valuePath []string,
+) {
+	message := fmt.Sprintf(
+		"undefined field '%s' of type '%s'",
+		fieldName,
+		t.Name(),
+	)
+	if suggestion != "" {
+		message += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+	}
+	errs.Add(ModelErr{
+		Code:     ErrUndefinedField,
+		Message:  message,
+		Location: valueLocation(valuePath),
+		FixIts: replaceFixIts(
+			fieldName,
+			suggestion,
+			valueLocation(valuePath),
+		),
+	})
+}
+
+// AddErrDuplicateInstance adds a new duplicate instance error
+// indicating an instance identifier redeclaration attempt
+func (errs *ModelErrors) AddErrDuplicateInstance(
+	instanceID string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code: ErrDuplicateInstance,
+		Message: fmt.Sprintf(
+			"redeclaration of instance '%s'",
+			instanceID,
+		),
+		Location: errLocation,
+	})
+}
+
+// AddErrInvalidRelationEdge adds a new invalid relation edge error
+// indicating that a relation edge doesn't connect the right instances
+func (errs *ModelErrors) AddErrInvalidRelationEdge(
+	message string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrInvalidRelationEdge,
+		Message:  message,
+		Location: errLocation,
+	})
+}
+
+// AddErrInvalidExample adds a new invalid example error
+// indicating that an example doesn't match the type it's declared for
+func (errs *ModelErrors) AddErrInvalidExample(
+	message string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrInvalidExample,
+		Message:  message,
+		Location: errLocation,
+	})
+}
+
+// AddErrInvalidReplacement adds a new invalid replacement error
+// indicating that a deprecation notice references an undefined replacement
+func (errs *ModelErrors) AddErrInvalidReplacement(
+	replacement string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code: ErrInvalidReplacement,
+		Message: fmt.Sprintf(
+			"undefined replacement '%s'",
+			replacement,
+		),
+		Location: errLocation,
+	})
+}
+
+// AddWarnDeprecatedTypeUsage adds a new deprecated type usage warning
+// indicating that a non-deprecated field or relation
+// references a deprecated type
+func (errs *ModelErrors) AddWarnDeprecatedTypeUsage(
+	deprecatedTypeName string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrDeprecatedTypeUsage,
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf(
+			"use of deprecated type '%s'",
+			deprecatedTypeName,
+		),
+		Location: errLocation,
+	})
+}
+
+// AddWarnPreludeOverride adds a new prelude override warning indicating
+// that a declaration extends or shadows the prelude type of the same name
+func (errs *ModelErrors) AddWarnPreludeOverride(
+	typeName string,
+	typeCategory string,
+	isExtension bool,
+	errLocation Location,
+) {
+	effect := "shadows"
+	if isExtension {
+		effect = "extends"
+	}
+	errs.Add(ModelErr{
+		Code:     ErrPreludeOverride,
+		Severity: SeverityWarning,
+		Message: fmt.Sprintf(
+			"%s type '%s' %s the prelude type of the same name",
+			typeCategory,
+			typeName,
+			effect,
+		),
+		Location: errLocation,
+	})
+}
+
+// AddWarnUnsupportedConstruct adds a new unsupported construct warning
+// indicating that an imported construct has no equivalent
+// and was dropped or approximated
+func (errs *ModelErrors) AddWarnUnsupportedConstruct(
+	message string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrUnsupportedConstruct,
+		Severity: SeverityWarning,
+		Message:  message,
+		Location: errLocation,
+	})
+}
+
+// AddWarnSchemaDrift adds a new schema drift warning indicating
+// that a deployed schema deviates from the document model
+func (errs *ModelErrors) AddWarnSchemaDrift(
+	message string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrSchemaDrift,
+		Severity: SeverityWarning,
+		Message:  message,
+		Location: errLocation,
+	})
+}
+
+// AddInfoRenamedType adds a new renamed type note indicating
+// that an imported definition was declared under a different name
+// to make it a valid and unique type name
+func (errs *ModelErrors) AddInfoRenamedType(
+	originalName string,
+	typeName string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrRenamedType,
+		Severity: SeverityInfo,
+		Message: fmt.Sprintf(
+			"'%s' renamed to type '%s'",
+			originalName,
+			typeName,
+		),
+		Location: errLocation,
+	})
+}
+
+// AddErrUnknownKey adds a new unknown key error indicating that
+// a key isn't defined by the document format. The suggestion
+// is the most similar known key and is omitted if empty
+func (errs *ModelErrors) AddErrUnknownKey(
+	key string,
+	suggestion string,
+	errLocation Location,
+) {
+	message := fmt.Sprintf("unknown key '%s'", key)
+	if suggestion != "" {
+		message += fmt.Sprintf(" (did you mean '%s'?)", suggestion)
+	}
+	errs.Add(ModelErr{
+		Code:     ErrUnknownKey,
+		Message:  message,
+		Location: errLocation,
+		FixIts:   replaceFixIts(key, suggestion, errLocation),
+	})
+}
+
+// AddErrDuplicateKey adds a new duplicate key error
+// indicating that a key is repeated in the same mapping
+func (errs *ModelErrors) AddErrDuplicateKey(
+	key string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrDuplicateKey,
+		Message:  fmt.Sprintf("duplicate key '%s'", key),
+		Location: errLocation,
+	})
+}
+
+// AddErrInvalidValueKind adds a new invalid value kind error
+// indicating that the value of a key is of the wrong kind
+func (errs *ModelErrors) AddErrInvalidValueKind(
+	key string,
+	expected string,
+	actual string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code: ErrInvalidValueKind,
+		Message: fmt.Sprintf(
+			"value of '%s' must be %s, got %s",
+			key,
+			expected,
+			actual,
+		),
+		Location: errLocation,
+	})
+}
+
+// AddErrMissingKey adds a new missing key error indicating
+// that a required key is either missing or declared without a value
+func (errs *ModelErrors) AddErrMissingKey(
+	key string,
+	errLocation Location,
+) {
+	errs.Add(ModelErr{
+		Code:     ErrMissingKey,
+		Message:  fmt.Sprintf("missing value of required key '%s'", key),
+		Location: errLocation,
+	})
 }
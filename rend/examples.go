@@ -0,0 +1,348 @@
+package rend
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// Example represents a JSON encoded example value of a type
+type Example struct {
+	JSON string
+
+	// Synthesized indicates whether or not the example was generated
+	// automatically because the type doesn't declare any examples
+	Synthesized bool
+}
+
+// typeExamples returns the declared examples of the given type
+func typeExamples(t AbstractType) []interface{} {
+	switch t := t.(type) {
+	case *ScalarType:
+		return t.Examples
+	case *EnumerationType:
+		return t.Examples
+	case *CompositeType:
+		return t.Examples
+	case *EntityType:
+		return t.Examples
+	case *EntityRelationType:
+		return t.Examples
+	}
+	return nil
+}
+
+// jsonValue converts a decoded YAML value to a JSON encodable value
+func jsonValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case []interface{}:
+		list := make([]interface{}, len(v))
+		for i, item := range v {
+			list[i] = jsonValue(item)
+		}
+		return list
+	case map[string]interface{}, document.Object:
+		fields, _ := objectValue(v)
+		obj := make(map[string]interface{}, len(fields))
+		for key, val := range fields {
+			obj[key] = jsonValue(val)
+		}
+		return obj
+	}
+	return value
+}
+
+// jsonObject represents a JSON object preserving the order of its fields
+type jsonObject struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+// MarshalJSON implements the Go JSON marshaller interface
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// orderedValue converts a decoded value of the given type
+// to a JSON encodable value listing the object fields in the document order.
+// Undefined fields are listed alphabetically after the defined ones
+func (d *Document) orderedValue(
+	t AbstractType,
+	value interface{},
+) interface{} {
+	complexType, isComplex := t.(ComplexType)
+	fields, isObject := objectValue(value)
+	if !isComplex || !isObject {
+		return jsonValue(value)
+	}
+
+	obj := jsonObject{values: make(map[string]interface{}, len(fields))}
+	for _, field := range d.OrderedFields(complexType) {
+		val, isSet := fields[field.Name]
+		if !isSet {
+			continue
+		}
+		if list, isList := val.([]interface{}); isList &&
+			field.IsList &&
+			field.Type != nil {
+			items := make([]interface{}, len(list))
+			for i, item := range list {
+				items[i] = d.orderedValue(field.Type, item)
+			}
+			val = items
+		} else if field.Type != nil {
+			val = d.orderedValue(field.Type, val)
+		} else {
+			val = jsonValue(val)
+		}
+		obj.keys = append(obj.keys, field.Name)
+		obj.values[field.Name] = val
+	}
+
+	metadata := complexType.MetaInformation()
+	undefinedFields := make([]string, 0)
+	for fieldName := range fields {
+		if _, isDefined := metadata[fieldName]; !isDefined {
+			undefinedFields = append(undefinedFields, fieldName)
+		}
+	}
+	sort.Strings(undefinedFields)
+	for _, fieldName := range undefinedFields {
+		obj.keys = append(obj.keys, fieldName)
+		obj.values[fieldName] = jsonValue(fields[fieldName])
+	}
+	return obj
+}
+
+// verifyExamples returns errors if any of the examples of the registered
+// types and their fields don't match their types, otherwise returns nil
+func (d *Document) verifyExamples() (errors ModelErrors) {
+	typeNames := make([]string, 0, len(d.Types))
+	for typeName, t := range d.Types {
+		if _, isRelation := t.(*EntityRelationType); isRelation {
+			// Relations are verified through their declaring entity types
+			continue
+		}
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	var errs ModelErrors
+	verify := func(t AbstractType) {
+		path := declarationPath(t)
+		for index, example := range typeExamples(t) {
+			errs.Add(d.VerifyValue(
+				t,
+				example,
+				appendPath(path, "examples", strconv.Itoa(index)),
+			)...)
+		}
+
+		complexType, isComplex := t.(ComplexType)
+		if !isComplex {
+			return
+		}
+		metadata := complexType.MetaInformation()
+		for _, fieldName := range sortedFieldNames(metadata) {
+			field := metadata[fieldName]
+			if field.Type == nil {
+				// Skip unresolved fields
+				continue
+			}
+			for index, example := range field.Examples {
+				errs.Add(d.VerifyFieldValue(
+					field,
+					example,
+					appendPath(
+						fieldPath(complexType, fieldName),
+						"examples",
+						strconv.Itoa(index),
+					),
+				)...)
+			}
+		}
+	}
+
+	for _, typeName := range typeNames {
+		t := d.Types[typeName]
+		verify(t)
+		if entityType, isEntity := t.(*EntityType); isEntity {
+			for _, relation := range d.OrderedRelations(entityType) {
+				verify(relation)
+			}
+		}
+	}
+
+	// Report mismatching values as invalid examples
+	for _, err := range errs {
+		errors.AddErrInvalidExample(err.Message, err.Location)
+	}
+	return errors
+}
+
+// synthesizeExample returns an example value of the given type
+// derived from the declared examples of the type and its fields.
+// Recursive types are cut off at the first repetition.
+// Returns nil if no valid example value can be derived
+func (d *Document) synthesizeExample(
+	t AbstractType,
+	visited map[AbstractType]bool,
+) interface{} {
+	if examples := typeExamples(t); len(examples) > 0 {
+		return jsonValue(examples[0])
+	}
+	if visited[t] {
+		return nil
+	}
+
+	switch typ := t.(type) {
+	case *ScalarType:
+		value := synthesizeScalar(typ)
+		if value == nil || len(d.VerifyValue(typ, value, nil)) > 0 {
+			return nil
+		}
+		return value
+	case *EnumerationType:
+		values := d.OrderedEnumerationValues(typ)
+		if len(values) < 1 {
+			return nil
+		}
+		return values[0].Name
+	case ComplexType:
+		visited[t] = true
+		defer delete(visited, t)
+
+		metadata := typ.MetaInformation()
+		obj := make(map[string]interface{}, len(metadata))
+		for fieldName, field := range metadata {
+			if len(field.Examples) > 0 {
+				obj[fieldName] = jsonValue(field.Examples[0])
+				continue
+			}
+			if field.Type == nil {
+				continue
+			}
+			value := d.synthesizeExample(field.Type, visited)
+			switch {
+			case field.IsList && value == nil:
+				// Cut off lists are empty
+				obj[fieldName] = []interface{}{}
+			case field.IsList:
+				obj[fieldName] = []interface{}{value}
+			case value != nil || field.Nullable:
+				obj[fieldName] = value
+			}
+			// Fields without a valid example value are omitted
+		}
+		return obj
+	}
+	return nil
+}
+
+// synthesizeScalar returns an example value of the given scalar type
+// satisfying its constraints, or nil if no such value can be derived
+// such as for types constrained by a pattern
+func synthesizeScalar(t *ScalarType) interface{} {
+	constraints := t.Constraints
+	switch t.Kind {
+	case document.StringKind:
+		if constraints.Pattern != nil {
+			return nil
+		}
+		example := "text"
+		if constraints.MinLength != nil &&
+			uint32(len(example)) < *constraints.MinLength {
+			example += strings.Repeat("x", int(*constraints.MinLength)-len(example))
+		}
+		if constraints.MaxLength != nil &&
+			uint32(len(example)) > *constraints.MaxLength {
+			example = example[:*constraints.MaxLength]
+		}
+		return example
+	case document.NumberKind, document.IntegerKind:
+		if t.Kind == document.NumberKind {
+			if constraints.Minimum != nil {
+				return *constraints.Minimum
+			}
+			if constraints.Maximum != nil && *constraints.Maximum < 0 {
+				return *constraints.Maximum
+			}
+			return 0
+		}
+		var example float64
+		if constraints.Minimum != nil {
+			example = math.Ceil(*constraints.Minimum)
+		} else if constraints.Maximum != nil && *constraints.Maximum < 0 {
+			example = math.Floor(*constraints.Maximum)
+		}
+		if constraints.Maximum != nil && example > *constraints.Maximum {
+			// No integer lies within the range
+			return nil
+		}
+		return int64(example)
+	case document.BooleanKind:
+		return true
+	case document.TimeKind:
+		return time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC)
+	}
+	return "value"
+}
+
+// Examples returns the pretty-printed JSON encoded examples of the given
+// type. An example is synthesized if the type doesn't declare any,
+// no examples are returned if no valid example can be synthesized
+func (d *Document) Examples(t AbstractType) []Example {
+	examples := typeExamples(t)
+	synthesized := false
+	if len(examples) < 1 {
+		example := d.synthesizeExample(t, make(map[AbstractType]bool))
+		if example == nil {
+			return nil
+		}
+		examples = []interface{}{example}
+		synthesized = true
+	}
+
+	encoded := make([]Example, len(examples))
+	for i, example := range examples {
+		buf, err := json.MarshalIndent(
+			d.orderedValue(t, example),
+			"",
+			"  ",
+		)
+		if err != nil {
+			buf = []byte(fmt.Sprintf("%v", example))
+		}
+		encoded[i] = Example{
+			JSON:        string(buf),
+			Synthesized: synthesized,
+		}
+	}
+	return encoded
+}
@@ -0,0 +1,107 @@
+package rend
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+// TestSynthesizedExamples verifies that synthesized examples
+// satisfy the constraints of scalar types
+func TestSynthesizedExamples(t *testing.T) {
+	model, errs := newModel(t, "scalar types:\n"+
+		"  Code:\n"+
+		"    kind: string\n"+
+		"    constraints:\n"+
+		"      pattern: '^[A-Z]{3}$'\n"+
+		"  Name:\n"+
+		"    kind: string\n"+
+		"    constraints:\n"+
+		"      min length: 6\n"+
+		"  Initials:\n"+
+		"    kind: string\n"+
+		"    constraints:\n"+
+		"      max length: 2\n"+
+		"  Count:\n"+
+		"    kind: integer\n"+
+		"    constraints:\n"+
+		"      minimum: 0.5\n"+
+		"  Debt:\n"+
+		"    kind: integer\n"+
+		"    constraints:\n"+
+		"      maximum: -1.5\n"+
+		"  Fraction:\n"+
+		"    kind: integer\n"+
+		"    constraints:\n"+
+		"      minimum: 0.2\n"+
+		"      maximum: 0.8\n"+
+		"  Ratio:\n"+
+		"    kind: number\n"+
+		"    constraints:\n"+
+		"      minimum: 0.5\n")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %#v", errs)
+	}
+	for typeName, expected := range map[string]string{
+		"Name":     `"textxx"`,
+		"Initials": `"te"`,
+		"Count":    "1",
+		"Debt":     "-2",
+		"Ratio":    "0.5",
+	} {
+		examples := model.Examples(model.ScalarTypes[typeName])
+		if len(examples) != 1 || examples[0].JSON != expected {
+			t.Fatalf(
+				"expected the example %s of '%s', got %#v",
+				expected,
+				typeName,
+				examples,
+			)
+		}
+	}
+
+	// No valid value can be synthesized for these types
+	for _, typeName := range []string{"Code", "Fraction"} {
+		if examples := model.Examples(model.ScalarTypes[typeName]); examples != nil {
+			t.Fatalf("unexpected examples of '%s': %#v", typeName, examples)
+		}
+	}
+}
+
+// TestSynthesizedExamplesFields verifies that fields without a valid
+// example value are omitted and that cut off lists are empty
+func TestSynthesizedExamplesFields(t *testing.T) {
+	model, errs := newModel(t, "scalar types:\n"+
+		"  Code:\n"+
+		"    kind: string\n"+
+		"    constraints:\n"+
+		"      pattern: '^[A-Z]{3}$'\n"+
+		"composite types:\n"+
+		"  Node:\n"+
+		"    meta:\n"+
+		"      code:\n"+
+		"        type: Code\n"+
+		"      parent:\n"+
+		"        type: Node\n"+
+		"        nullable: true\n"+
+		"      children:\n"+
+		"        type: List<Node>\n")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %#v", errs)
+	}
+	examples := model.Examples(model.CompositeTypes["Node"])
+	if len(examples) != 1 {
+		t.Fatalf("expected 1 example, got %#v", examples)
+	}
+	var example map[string]interface{}
+	if err := json.Unmarshal([]byte(examples[0].JSON), &example); err != nil {
+		t.Fatalf("couldn't decode example: %s", err)
+	}
+	expected := map[string]interface{}{
+		"parent":   nil,
+		"children": []interface{}{},
+	}
+	if !reflect.DeepEqual(example, expected) {
+		t.Fatalf("unexpected example: %s", examples[0].JSON)
+	}
+}
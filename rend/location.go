@@ -0,0 +1,175 @@
+package rend
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Location represents the location of a model error
+type Location struct {
+	// Description describes the location in a human readable form
+	Description string
+
+	// Path is the path of mapping keys and list indexes leading
+	// to the location in the source YAML document such as
+	// ["entity types", "Actor", "meta", "social"]
+	Path []string
+
+	// Occurrence is the 0-based occurrence of the key of the path
+	// if the key is repeated in the source YAML document
+	Occurrence int
+}
+
+// String implements the fmt.Stringer interface
+func (l Location) String() string {
+	return l.Description
+}
+
+// sectionKeys maps the type categories to the keys of the document
+// sections declaring them
+var sectionKeys = map[TypeCategory]string{
+	Scalar:      "scalar types",
+	Enumeration: "enumeration types",
+	Composite:   "composite types",
+	Entity:      "entity types",
+}
+
+// appendPath returns a copy of the given path extended by the given keys
+func appendPath(path []string, keys ...string) []string {
+	extended := make([]string, len(path), len(path)+len(keys))
+	copy(extended, path)
+	return append(extended, keys...)
+}
+
+// FormatPath formats the given path in a human readable form
+// such as "entities.Actor.keanu.genre[1]"
+func FormatPath(path []string) string {
+	var builder strings.Builder
+	for i, key := range path {
+		if _, err := strconv.Atoi(key); err == nil && i > 0 {
+			builder.WriteString("[" + key + "]")
+			continue
+		}
+		if i > 0 {
+			builder.WriteByte('.')
+		}
+		builder.WriteString(key)
+	}
+	return builder.String()
+}
+
+// typePath returns the path of the declaration of the given type
+func typePath(category TypeCategory, typeName string) []string {
+	return []string{sectionKeys[category], typeName}
+}
+
+// typeLocation returns the location of the declaration of the given type
+func typeLocation(
+	category TypeCategory,
+	typeName string,
+	description string,
+) Location {
+	return Location{
+		Description: description,
+		Path:        typePath(category, typeName),
+	}
+}
+
+// relationPath returns the path of the declaration of the given relation
+// of the given entity type
+func relationPath(entityTypeName, relationName string) []string {
+	return []string{
+		sectionKeys[Entity],
+		entityTypeName,
+		"relations",
+		relationName,
+	}
+}
+
+// relationLocation returns the location of the declaration
+// of the given relation of the given entity type
+func relationLocation(
+	entityTypeName,
+	relationName,
+	description string,
+) Location {
+	return Location{
+		Description: description,
+		Path:        relationPath(entityTypeName, relationName),
+	}
+}
+
+// declarationPath returns the path of the declaration of the given type.
+// Relations are declared by entity types
+func declarationPath(t AbstractType) []string {
+	if relation, isRelation := t.(*EntityRelationType); isRelation {
+		return relationPath(relation.DeclaringTypeName(), relation.RelationName)
+	}
+	return typePath(t.TypeCategory(), t.Name())
+}
+
+// DeclarationLocation returns the location
+// of the declaration of the given type
+func DeclarationLocation(t AbstractType, description string) Location {
+	return Location{
+		Description: description,
+		Path:        declarationPath(t),
+	}
+}
+
+// fieldPath returns the path of the declaration
+// of the given metadata field of the given type
+func fieldPath(origin ComplexType, fieldName string) []string {
+	return appendPath(declarationPath(origin), "meta", fieldName)
+}
+
+// fieldLocation returns the location of the declaration
+// of the given metadata field of the given type
+func fieldLocation(origin ComplexType, fieldName string) Location {
+	return Location{
+		Description: fmt.Sprintf(
+			"field '%s' of type '%s'",
+			fieldName,
+			origin.Name(),
+		),
+		Path: fieldPath(origin, fieldName),
+	}
+}
+
+// fieldTypeLocation returns the location of the type
+// of the given metadata field of the given type
+func fieldTypeLocation(origin ComplexType, fieldName string) Location {
+	return Location{
+		Description: fmt.Sprintf(
+			"type of field '%s' of type '%s'",
+			fieldName,
+			origin.Name(),
+		),
+		Path: appendPath(fieldPath(origin, fieldName), "type"),
+	}
+}
+
+// relatedTypeLocation returns the location of the related type
+// of the given relation of the given entity type
+func relatedTypeLocation(entityTypeName, relationName string) Location {
+	return Location{
+		Description: fmt.Sprintf(
+			"related type of relation '%s' of entity type '%s'",
+			relationName,
+			entityTypeName,
+		),
+		Path: appendPath(
+			relationPath(entityTypeName, relationName),
+			"related type",
+		),
+	}
+}
+
+// valueLocation returns the location of the value at the given path
+func valueLocation(path []string) Location {
+	return Location{
+		Description: FormatPath(path),
+		Path:        path,
+	}
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/romshark/TypeBook/document"
 )
 
-// NewModel initializes a new document model based on a document template
+// NewModel initializes a new document model based on a document template.
+// The build time is stamped into the document metadata,
+// a zero build time omits it for reproducible output
 func NewModel(
 	doc *document.Document,
+	buildTime time.Time,
 ) (
 	model *Document,
 	errors ModelErrors,
@@ -22,7 +25,7 @@ func NewModel(
 
 	// Create a document model instance
 	model, err = NewDocument(
-		time.Now().UTC(),
+		buildTime,
 		doc.Title,
 		doc.Author,
 		doc.Version,
@@ -32,26 +35,53 @@ func NewModel(
 		return nil, nil, nil, err
 	}
 
+	// Report unknown and duplicate keys, values of the wrong kind
+	// and missing required keys
+	errors.Add(verifyStructure(doc)...)
+
+	// Type references rejected by the structure verification are empty,
+	// they're poisoned to not report them again
+	model.poison("")
+
+	// Set the naming rules before registering any types
+	errors.Add(model.SetNamingRules(doc.Naming)...)
+
+	// Declare the entity types before registering any types
+	// to recognize them in the fields of composite types
+	model.declaredEntityTypes = make(map[string]bool, len(doc.EntityTypes))
+	for typeName := range doc.EntityTypes {
+		model.declaredEntityTypes[typeName] = true
+	}
+
+	// Register the prelude types before the declared ones
+	scalarTypes, errs := model.RegisterPrelude(doc)
+	errors.Add(errs...)
+
 	// Try to register the new scalar types
-	for typeName, scalarType := range doc.ScalarTypes {
-		errors.Add(model.RegisterScalarType(
-			typeName,
-			scalarType.Description,
-		)...)
+	for typeName, scalarType := range scalarTypes {
+		errors.Add(model.RegisterScalarType(typeName, scalarType)...)
 	}
 
 	// Try to register the new enumeration types
 	for typeName, enumerationType := range doc.EnumerationTypes {
 		errors.Add(model.RegisterEnumerationType(
 			typeName,
-			enumerationType.Description,
-			enumerationType.Values,
+			enumerationType,
 		)...)
 	}
 
 	errors.Add(model.RegisterCompositeTypes(doc.CompositeTypes)...)
 	errors.Add(model.RegisterEntityTypes(doc.EntityTypes)...)
 
+	// Verify the examples of the registered types
+	errors.Add(model.verifyExamples()...)
+
+	// Verify the deprecation notices of the registered types
+	errors.Add(model.verifyDeprecations()...)
+
+	// Report every root cause only once
+	errors.Deduplicate()
+
 	stats = &ModelInitStats{}
 	return model, errors, stats, nil
 }
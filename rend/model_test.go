@@ -0,0 +1,23 @@
+package rend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// newModel initializes the model of a test schema with the given
+// declarations and returns it along with the reported problems
+func newModel(t *testing.T, declarations string) (*Document, ModelErrors) {
+	t.Helper()
+	doc, _, err := document.New([]byte("title: Test\n" + declarations))
+	if err != nil {
+		t.Fatalf("couldn't parse schema: %s", err)
+	}
+	model, errs, _, err := NewModel(doc, time.Time{})
+	if err != nil {
+		t.Fatalf("couldn't initialize model: %s", err)
+	}
+	return model, errs
+}
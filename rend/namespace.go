@@ -0,0 +1,156 @@
+package rend
+
+import (
+	"github.com/romshark/TypeBook/document"
+)
+
+// Namespace returns the namespace of the given type such as "billing"
+// for "billing.Invoice". Relation types belong to the namespace
+// of their declaring entity type.
+// Returns an empty string if the type isn't namespaced
+func Namespace(t AbstractType) string {
+	name := t.Name()
+	if relation, isRelation := t.(*EntityRelationType); isRelation {
+		name = relation.DeclaringTypeName()
+	}
+	namespace, _ := document.SplitTypeName(name)
+	return namespace
+}
+
+// LocalName returns the name of the given type without its namespace
+// such as "Invoice" for "billing.Invoice"
+func LocalName(t AbstractType) string {
+	if _, isRelation := t.(*EntityRelationType); isRelation {
+		return t.Name()
+	}
+	_, localName := document.SplitTypeName(t.Name())
+	return localName
+}
+
+// qualifyTypeName returns the qualified name of the type referenced
+// by the given name from within the given namespace.
+// Registered, forward-declared and poisoned types are considered
+func (d *Document) qualifyTypeName(
+	forwardDeclared Types,
+	namespace string,
+	typeName string,
+) string {
+	return document.QualifyTypeName(
+		namespace,
+		typeName,
+		func(name string) bool {
+			if _, isDeclared := d.Types[name]; isDeclared {
+				return true
+			}
+			if _, isDeclared := forwardDeclared[name]; isDeclared {
+				return true
+			}
+			return d.poisoned[name] || d.declaredEntityTypes[name]
+		},
+	)
+}
+
+// LookupType returns the type referenced by the given name
+// from within the given namespace and true if it's registered,
+// otherwise returns false. References resolve to the type declared
+// in the innermost enclosing namespace
+func (d *Document) LookupType(
+	namespace string,
+	typeName string,
+) (AbstractType, bool) {
+	t, isDefined := d.Types[d.qualifyTypeName(nil, namespace, typeName)]
+	return t, isDefined
+}
+
+// NamespaceGroup represents the types of a namespace
+type NamespaceGroup struct {
+	// Namespace is the name of the namespace,
+	// it's empty for types that aren't namespaced
+	Namespace string
+
+	Types []AbstractType
+}
+
+// groupByNamespace groups the given ordered types by their namespaces.
+// Types that aren't namespaced are grouped first, namespaces are listed
+// in the order of their first type
+func groupByNamespace(types []AbstractType) []NamespaceGroup {
+	groups := []NamespaceGroup{{}}
+	indexes := map[string]int{"": 0}
+	for _, t := range types {
+		namespace := Namespace(t)
+		index, isGrouped := indexes[namespace]
+		if !isGrouped {
+			index = len(groups)
+			indexes[namespace] = index
+			groups = append(groups, NamespaceGroup{Namespace: namespace})
+		}
+		groups[index].Types = append(groups[index].Types, t)
+	}
+	if len(groups[0].Types) < 1 {
+		return groups[1:]
+	}
+	return groups
+}
+
+// ScalarTypeNamespaces returns the ordered declared scalar types
+// grouped by their namespaces
+func (d *Document) ScalarTypeNamespaces() []NamespaceGroup {
+	ordered := d.DeclaredScalarTypes()
+	types := make([]AbstractType, len(ordered))
+	for i, t := range ordered {
+		types[i] = t
+	}
+	return groupByNamespace(types)
+}
+
+// EnumerationTypeNamespaces returns the ordered enumeration types
+// grouped by their namespaces
+func (d *Document) EnumerationTypeNamespaces() []NamespaceGroup {
+	ordered := d.OrderedEnumerationTypes()
+	types := make([]AbstractType, len(ordered))
+	for i, t := range ordered {
+		types[i] = t
+	}
+	return groupByNamespace(types)
+}
+
+// CompositeTypeNamespaces returns the ordered composite types
+// grouped by their namespaces
+func (d *Document) CompositeTypeNamespaces() []NamespaceGroup {
+	ordered := d.OrderedCompositeTypes()
+	types := make([]AbstractType, len(ordered))
+	for i, t := range ordered {
+		types[i] = t
+	}
+	return groupByNamespace(types)
+}
+
+// EntityTypeNamespaces returns the ordered entity types
+// grouped by their namespaces
+func (d *Document) EntityTypeNamespaces() []NamespaceGroup {
+	ordered := d.OrderedEntityTypes()
+	types := make([]AbstractType, len(ordered))
+	for i, t := range ordered {
+		types[i] = t
+	}
+	return groupByNamespace(types)
+}
+
+// RelationTypeNamespaces returns the relation types ordered
+// by their declaring entity types grouped by their namespaces
+func (d *Document) RelationTypeNamespaces() []NamespaceGroup {
+	types := make([]AbstractType, 0, len(d.Relations))
+	for _, entity := range d.OrderedEntityTypes() {
+		for _, relation := range d.OrderedRelations(entity) {
+			types = append(types, relation)
+		}
+	}
+	return groupByNamespace(types)
+}
+
+// LocalName returns the name of the given type of the group
+// without the namespace of the group
+func (g NamespaceGroup) LocalName(t AbstractType) string {
+	return LocalName(t)
+}
@@ -0,0 +1,32 @@
+package rend
+
+import "testing"
+
+// TestNamespaces verifies that references are resolved
+// relative to the namespace of the referencing type
+func TestNamespaces(t *testing.T) {
+	model, errs := newModel(t, "scalar types:\n"+
+		"  Amount:\n"+
+		"    kind: string\n"+
+		"  billing.Amount:\n"+
+		"    kind: number\n"+
+		"  OAuth2Token:\n"+
+		"    kind: string\n"+
+		"composite types:\n"+
+		"  billing.Money:\n"+
+		"    meta:\n"+
+		"      amount:\n"+
+		"        type: Amount\n"+
+		"      token:\n"+
+		"        type: OAuth2Token\n")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %#v", errs)
+	}
+	money := model.CompositeTypes["billing.Money"]
+	if name := money.Metadata["amount"].Type.Name(); name != "billing.Amount" {
+		t.Fatalf("expected billing.Amount, got %s", name)
+	}
+	if name := money.Metadata["token"].Type.Name(); name != "OAuth2Token" {
+		t.Fatalf("expected OAuth2Token, got %s", name)
+	}
+}
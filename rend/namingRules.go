@@ -0,0 +1,71 @@
+package rend
+
+import (
+	"regexp"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+var defaultTypeNameRule = regexp.MustCompile(document.DefaultTypeNameRule)
+var defaultNamespaceRule = regexp.MustCompile(document.DefaultNamespaceRule)
+
+// namingRules represents the compiled naming rules of a document.
+// Missing rules fall back to the default rules
+type namingRules struct {
+	namespaces *regexp.Regexp
+	types      map[TypeCategory]*regexp.Regexp
+}
+
+// typeNameRule returns the naming rule of the type names
+// of the given category
+func (r namingRules) typeNameRule(category TypeCategory) *regexp.Regexp {
+	if rule, isDefined := r.types[category]; isDefined {
+		return rule
+	}
+	return defaultTypeNameRule
+}
+
+// namespaceRule returns the naming rule of namespaces
+func (r namingRules) namespaceRule() *regexp.Regexp {
+	if r.namespaces != nil {
+		return r.namespaces
+	}
+	return defaultNamespaceRule
+}
+
+// SetNamingRules sets the naming rules the names of types registered
+// afterwards must match. Invalid rules are reported as errors
+// and fall back to the default rules
+func (d *Document) SetNamingRules(rules document.NamingRules) (errors ModelErrors) {
+	d.naming = namingRules{types: make(map[TypeCategory]*regexp.Regexp)}
+
+	compile := func(key, rule string) *regexp.Regexp {
+		if rule == "" {
+			return nil
+		}
+		compiled, err := regexp.Compile(rule)
+		if err != nil {
+			errors.AddErrInvalidNamingRule(key, rule, err)
+			return nil
+		}
+		return compiled
+	}
+
+	d.naming.namespaces = compile("namespaces", rules.Namespaces)
+	for _, rule := range []struct {
+		category TypeCategory
+		key      string
+		rule     string
+	}{
+		{Scalar, "scalar types", rules.ScalarTypes},
+		{Enumeration, "enumeration types", rules.EnumerationTypes},
+		{Composite, "composite types", rules.CompositeTypes},
+		{Entity, "entity types", rules.EntityTypes},
+		{Relation, "relation types", rules.RelationTypes},
+	} {
+		if compiled := compile(rule.key, rule.rule); compiled != nil {
+			d.naming.types[rule.category] = compiled
+		}
+	}
+	return errors
+}
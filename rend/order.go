@@ -0,0 +1,165 @@
+package rend
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Order represents the order types, fields, relations
+// and enumeration values are listed in
+type Order uint8
+
+const (
+	// DeclarationOrder lists items in the order they're declared in
+	DeclarationOrder Order = iota
+
+	// AlphabeticalOrder lists items sorted by their names
+	AlphabeticalOrder
+)
+
+// String stringifies the value
+func (o Order) String() string {
+	switch o {
+	case DeclarationOrder:
+		return "declaration"
+	case AlphabeticalOrder:
+		return "alphabetical"
+	}
+	panic(fmt.Errorf("couldn't stringify invalid Order value: %d", o))
+}
+
+// FromString initializes the value from a string
+func (o *Order) FromString(str string) error {
+	switch str {
+	case "declaration":
+		*o = DeclarationOrder
+		return nil
+	case "alphabetical":
+		*o = AlphabeticalOrder
+		return nil
+	}
+	return fmt.Errorf("invalid order: '%s'", str)
+}
+
+// less returns true if the item declared at index i named nameI
+// precedes the item declared at index j named nameJ
+func (o Order) less(i int, nameI string, j int, nameJ string) bool {
+	if o == DeclarationOrder && i != j {
+		return i < j
+	}
+	return nameI < nameJ
+}
+
+// OrderedScalarTypes returns the scalar types in the document order
+func (d *Document) OrderedScalarTypes() []*ScalarType {
+	types := make([]*ScalarType, 0, len(d.ScalarTypes))
+	for _, t := range d.ScalarTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return d.Order.less(
+			types[i].Index, types[i].TypeName,
+			types[j].Index, types[j].TypeName,
+		)
+	})
+	return types
+}
+
+// OrderedEnumerationTypes returns the enumeration types
+// in the document order
+func (d *Document) OrderedEnumerationTypes() []*EnumerationType {
+	types := make([]*EnumerationType, 0, len(d.EnumerationTypes))
+	for _, t := range d.EnumerationTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return d.Order.less(
+			types[i].Index, types[i].TypeName,
+			types[j].Index, types[j].TypeName,
+		)
+	})
+	return types
+}
+
+// OrderedCompositeTypes returns the composite types in the document order
+func (d *Document) OrderedCompositeTypes() []*CompositeType {
+	types := make([]*CompositeType, 0, len(d.CompositeTypes))
+	for _, t := range d.CompositeTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return d.Order.less(
+			types[i].Index, types[i].TypeName,
+			types[j].Index, types[j].TypeName,
+		)
+	})
+	return types
+}
+
+// OrderedEntityTypes returns the entity types in the document order
+func (d *Document) OrderedEntityTypes() []*EntityType {
+	types := make([]*EntityType, 0, len(d.EntityTypes))
+	for _, t := range d.EntityTypes {
+		types = append(types, t)
+	}
+	sort.Slice(types, func(i, j int) bool {
+		return d.Order.less(
+			types[i].Index, types[i].TypeName,
+			types[j].Index, types[j].TypeName,
+		)
+	})
+	return types
+}
+
+// OrderedFields returns the metadata fields of the given type
+// in the document order
+func (d *Document) OrderedFields(t ComplexType) []TypedField {
+	metadata := t.MetaInformation()
+	fields := make([]TypedField, 0, len(metadata))
+	for fieldName, field := range metadata {
+		// Make sure the name is set even for unresolved fields
+		field.Name = fieldName
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return d.Order.less(
+			fields[i].Index, fields[i].Name,
+			fields[j].Index, fields[j].Name,
+		)
+	})
+	return fields
+}
+
+// OrderedRelations returns the relations of the given entity type
+// in the document order
+func (d *Document) OrderedRelations(t *EntityType) []*EntityRelationType {
+	relations := make([]*EntityRelationType, 0, len(t.Relations))
+	for _, relation := range t.Relations {
+		relations = append(relations, relation)
+	}
+	sort.Slice(relations, func(i, j int) bool {
+		return d.Order.less(
+			relations[i].Index, relations[i].RelationName,
+			relations[j].Index, relations[j].RelationName,
+		)
+	})
+	return relations
+}
+
+// OrderedEnumerationValues returns the values of the given
+// enumeration type in the document order
+func (d *Document) OrderedEnumerationValues(
+	t *EnumerationType,
+) []EnumerationValue {
+	values := make([]EnumerationValue, 0, len(t.Values))
+	for _, value := range t.Values {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return d.Order.less(
+			values[i].Index, values[i].Name,
+			values[j].Index, values[j].Name,
+		)
+	})
+	return values
+}
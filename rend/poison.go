@@ -0,0 +1,60 @@
+package rend
+
+// poison marks the type of the given name as poisoned.
+// Poisoned types failed the verification and aren't registered,
+// references to them aren't reported since they're caused
+// by the errors of the poisoned type
+func (d *Document) poison(typeName string) {
+	if d.poisoned == nil {
+		d.poisoned = make(map[string]bool)
+	}
+	d.poisoned[typeName] = true
+}
+
+// isPoisoned returns true if the type of the given name is poisoned
+// and no other type of the same name is registered, otherwise false
+func (d *Document) isPoisoned(typeName string) bool {
+	if _, isRegistered := d.Types[typeName]; isRegistered {
+		return false
+	}
+	return d.poisoned[typeName]
+}
+
+// dependsOnPoisoned returns true if any field of the given type or,
+// in case of an entity type, any of its relations references
+// a poisoned type, otherwise returns false
+func (d *Document) dependsOnPoisoned(t ComplexType) bool {
+	for _, field := range t.MetaInformation() {
+		if d.isPoisoned(field.TypeName) {
+			return true
+		}
+	}
+	if entity, isEntity := t.(*EntityType); isEntity {
+		for _, relation := range entity.Relations {
+			if d.isPoisoned(relation.RelatedTypeName) ||
+				d.dependsOnPoisoned(relation) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// poisonDependents poisons the given types depending on poisoned types
+// until none of the remaining types does and returns the remaining types
+func (d *Document) poisonDependents(types []ComplexType) []ComplexType {
+	for {
+		remaining := make([]ComplexType, 0, len(types))
+		for _, t := range types {
+			if d.dependsOnPoisoned(t) {
+				d.poison(t.Name())
+				continue
+			}
+			remaining = append(remaining, t)
+		}
+		if len(remaining) == len(types) {
+			return remaining
+		}
+		types = remaining
+	}
+}
@@ -0,0 +1,57 @@
+package rend
+
+import "testing"
+
+// TestPoisonRootCauses verifies that references to types
+// failing the verification aren't reported as errors
+func TestPoisonRootCauses(t *testing.T) {
+	_, errs := newModel(t, "scalar types:\n"+
+		"  Identifier:\n"+
+		"    kind: integer\n"+
+		"    constraints:\n"+
+		"      minimum: 10\n"+
+		"      maximum: 0\n"+
+		"composite types:\n"+
+		"  Contact:\n"+
+		"    meta:\n"+
+		"      id:\n"+
+		"        type: Identifier\n"+
+		"entity types:\n"+
+		"  Person:\n"+
+		"    meta:\n"+
+		"      contact:\n"+
+		"        type: Contact\n"+
+		"  Movie:\n"+
+		"    relations:\n"+
+		"      Actors:\n"+
+		"        type: ActedIn\n"+
+		"        related type: Person\n")
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 problem, got %#v", errs)
+	}
+	if errs[0].Code != ErrInvalidScalarConstraint {
+		t.Fatalf("unexpected problem: %#v", errs[0])
+	}
+}
+
+// TestPoisonRejectedReferences verifies that type references rejected
+// by the structure verification are reported only once
+func TestPoisonRejectedReferences(t *testing.T) {
+	_, errs := newModel(t, "scalar types:\n"+
+		"  String:\n"+
+		"    kind: string\n"+
+		"entity types:\n"+
+		"  Movie:\n"+
+		"    meta:\n"+
+		"      title:\n"+
+		"        type: [String]\n"+
+		"    relations:\n"+
+		"      Sequels:\n"+
+		"        related type: Movie\n")
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 problems, got %#v", errs)
+	}
+	if errs[0].Code != ErrInvalidValueKind || errs[1].Code != ErrMissingKey {
+		t.Fatalf("unexpected problems: %#v", errs)
+	}
+}
@@ -0,0 +1,213 @@
+package rend
+
+import (
+	_ "embed"
+	"fmt"
+	"sort"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// preludeSource is the document declaring the prelude types
+//
+//go:embed prelude.yml
+var preludeSource []byte
+
+// preludeMappings maps the prelude types to the native types
+// code generators map their values to by default
+var preludeMappings = map[string]map[string]string{
+	"Date": {
+		"postgres": "date",
+		"avro":     "date",
+		"proto":    "google.type.Date",
+		"cypher":   "DATE",
+	},
+	"UUID": {
+		"postgres": "uuid",
+		"avro":     "uuid",
+		"proto":    "string",
+		"cypher":   "STRING",
+	},
+}
+
+// Prelude returns the scalar types declared by the prelude
+func Prelude() document.ScalarTypes {
+	prelude, _, err := document.New(preludeSource)
+	if err != nil {
+		panic(fmt.Errorf("invalid prelude: %s", err))
+	}
+	return prelude.ScalarTypes
+}
+
+// extendPreludeType returns the given user declaration
+// inheriting the kind, the description, the constraints
+// and the examples it leaves undefined from the given prelude type
+func extendPreludeType(
+	preludeType document.ScalarType,
+	userType document.ScalarType,
+) document.ScalarType {
+	if userType.Kind == document.AnyKind {
+		userType.Kind = preludeType.Kind
+	}
+	if userType.Description == "" {
+		userType.Description = preludeType.Description
+	}
+	if userType.Examples == nil {
+		userType.Examples = preludeType.Examples
+	}
+	constraints := &userType.Constraints
+	if constraints.Pattern == "" {
+		constraints.Pattern = preludeType.Constraints.Pattern
+	}
+	if constraints.Minimum == nil {
+		constraints.Minimum = preludeType.Constraints.Minimum
+	}
+	if constraints.Maximum == nil {
+		constraints.Maximum = preludeType.Constraints.Maximum
+	}
+	if constraints.MinLength == nil {
+		constraints.MinLength = preludeType.Constraints.MinLength
+	}
+	if constraints.MaxLength == nil {
+		constraints.MaxLength = preludeType.Constraints.MaxLength
+	}
+	return userType
+}
+
+// declaredCategory returns the category of the user declaration
+// of the given type name and false if the name isn't declared
+func declaredCategory(
+	doc *document.Document,
+	typeName string,
+) (TypeCategory, bool) {
+	if _, isDeclared := doc.ScalarTypes[typeName]; isDeclared {
+		return Scalar, true
+	}
+	if _, isDeclared := doc.EnumerationTypes[typeName]; isDeclared {
+		return Enumeration, true
+	}
+	if _, isDeclared := doc.CompositeTypes[typeName]; isDeclared {
+		return Composite, true
+	}
+	if _, isDeclared := doc.EntityTypes[typeName]; isDeclared {
+		return Entity, true
+	}
+	return 0, false
+}
+
+// RegisterPrelude registers the prelude types if the given document
+// enables the prelude. Scalar types of the same name and a compatible
+// kind extend the prelude types, other declarations of the same name
+// shadow them. Returns the scalar types of the document
+// that remain to be registered
+func (d *Document) RegisterPrelude(doc *document.Document) (
+	remaining document.ScalarTypes,
+	errors ModelErrors,
+) {
+	if !doc.Prelude {
+		return doc.ScalarTypes, nil
+	}
+
+	remaining = make(document.ScalarTypes, len(doc.ScalarTypes))
+	for typeName, scalarType := range doc.ScalarTypes {
+		remaining[typeName] = scalarType
+	}
+
+	prelude := Prelude()
+	typeNames := make([]string, 0, len(prelude))
+	for typeName := range prelude {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		preludeType := prelude[typeName]
+
+		if category, isDeclared := declaredCategory(doc, typeName); isDeclared {
+			userType := doc.ScalarTypes[typeName]
+			isExtension := category == Scalar &&
+				(userType.Kind == document.AnyKind ||
+					userType.Kind == preludeType.Kind)
+			errors.AddWarnPreludeOverride(
+				typeName,
+				category.String(),
+				isExtension,
+				typeLocation(
+					category,
+					typeName,
+					fmt.Sprintf("%s type declaration", category),
+				),
+			)
+			if !isExtension {
+				// The user declaration shadows the prelude type
+				continue
+			}
+
+			extended := extendPreludeType(preludeType, userType)
+			delete(remaining, typeName)
+			errors.Add(d.registerScalarType(nil, &ScalarType{
+				TypeName:    typeName,
+				Description: extended.Description,
+				Kind:        extended.Kind,
+				Examples:    extended.Examples,
+				Deprecated:  newDeprecation(extended.Deprecated),
+				Index:       extended.Index,
+				Mappings:    preludeMappings[typeName],
+			}, extended.Constraints)...)
+			continue
+		}
+
+		// Prelude types are exempt from the naming rules
+		constraints, errs := verifyScalarConstraints(
+			typeName,
+			preludeType.Kind,
+			preludeType.Constraints,
+		)
+		if errs.HasErrors() {
+			panic(fmt.Errorf(
+				"invalid prelude type '%s': %s",
+				typeName,
+				errs[0].Message,
+			))
+		}
+		newType := &ScalarType{
+			TypeName:    typeName,
+			Description: preludeType.Description,
+			Kind:        preludeType.Kind,
+			Constraints: constraints,
+			Examples:    preludeType.Examples,
+			Index:       preludeType.Index,
+			Prelude:     true,
+			Mappings:    preludeMappings[typeName],
+		}
+		d.ScalarTypes[typeName] = newType
+		d.Types[typeName] = newType
+	}
+	return remaining, errors
+}
+
+// DeclaredScalarTypes returns the scalar types
+// declared by the document in the document order
+func (d *Document) DeclaredScalarTypes() []*ScalarType {
+	ordered := d.OrderedScalarTypes()
+	types := make([]*ScalarType, 0, len(ordered))
+	for _, t := range ordered {
+		if !t.Prelude {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// PreludeTypes returns the registered prelude types
+// neither extended nor shadowed by the document in the document order
+func (d *Document) PreludeTypes() []*ScalarType {
+	ordered := d.OrderedScalarTypes()
+	types := make([]*ScalarType, 0, len(ordered))
+	for _, t := range ordered {
+		if t.Prelude {
+			types = append(types, t)
+		}
+	}
+	return types
+}
@@ -0,0 +1,47 @@
+package rend
+
+import (
+	"testing"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// TestPrelude verifies that the prelude types are registered
+// and that declarations of the same name extend or shadow them
+func TestPrelude(t *testing.T) {
+	model, errs := newModel(t, "prelude: true\n"+
+		"scalar types:\n"+
+		"  UUID:\n"+
+		"    description: Identifies a record\n"+
+		"  URL:\n"+
+		"    kind: integer\n"+
+		"composite types:\n"+
+		"  Contact:\n"+
+		"    meta:\n"+
+		"      id:\n"+
+		"        type: UUID\n"+
+		"      email:\n"+
+		"        type: EmailAddress\n")
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 problems, got %#v", errs)
+	}
+	for _, err := range errs {
+		if err.Code != ErrPreludeOverride {
+			t.Fatalf("unexpected problem: %#v", err)
+		}
+	}
+
+	uuid := model.ScalarTypes["UUID"]
+	if uuid.Prelude || uuid.Constraints.Pattern == nil {
+		t.Fatalf("expected UUID to extend the prelude type, got %#v", uuid)
+	}
+	if uuid.Mappings["postgres"] != "uuid" {
+		t.Fatalf("expected the mappings to be kept, got %v", uuid.Mappings)
+	}
+	if url := model.ScalarTypes["URL"]; url.Kind != document.IntegerKind {
+		t.Fatalf("expected URL to shadow the prelude type, got %#v", url)
+	}
+	if email := model.ScalarTypes["EmailAddress"]; !email.Prelude {
+		t.Fatalf("expected EmailAddress to be a prelude type, got %#v", email)
+	}
+}
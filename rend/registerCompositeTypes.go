@@ -7,55 +7,67 @@ import (
 // registerCompositeTypes registers new composite types.
 // It will automatically set the type names as well as
 // the names and types of the metadata fields.
-// It won't register in case of type name collisions returning an error
+// Composite types may reference each other and themselves
+// as long as they're not infinitely sized.
+// Types failing the verification and types depending on them
+// are poisoned instead of being registered
 func (d *Document) registerCompositeTypes(
 	newTypes CompositeTypes,
 ) (errors ModelErrors) {
+	// Verify the type names forward declaring the valid types
+	// to allow composite types to reference each other
+	forwardDeclared := make(Types, len(newTypes))
 	for typeName, newType := range newTypes {
 		newType.TypeName = typeName
 
-		// Verify type name
-		errors.Add(d.verifyTypeName(
+		declarationLocation := typeLocation(
+			Composite,
 			typeName,
 			"composite type declaration",
-		)...)
-		if errors.HasErrors() {
-			// Don't evaluate further in case of illegal name
+		)
+
+		// Verify type name
+		errs := d.verifyTypeName(Composite, typeName, declarationLocation)
+		if !errs.HasErrors() {
+			errs.Add(d.verifyType(nil, typeName, declarationLocation)...)
+		}
+		if errs.HasErrors() {
+			// Don't evaluate further in case of an invalid type
+			errors.Add(errs...)
+			d.poison(typeName)
 			continue
 		}
+		forwardDeclared[typeName] = newType
+	}
 
-		errors.Add(d.verifyType(
-			nil,
-			typeName,
-			"composite type declaration", // error location
-		)...)
-		if errors.HasErrors() {
-			// Don't evaluate further in case of invalid type
+	// Verify metadata
+	verified := make([]ComplexType, 0, len(forwardDeclared))
+	for typeName, newType := range forwardDeclared {
+		newType := newType.(*CompositeType)
+		errs := d.verifyMetadataIntegrity(forwardDeclared, newType)
+		if errs.HasErrors() {
+			errors.Add(errs...)
+			d.poison(typeName)
 			continue
 		}
-
-		// Verify metadata
-		errors.Add(d.verifyMetadataIntegrity(
-			nil,
-			newType,
-		)...)
+		verified = append(verified, newType)
 	}
 
-	// Return errors if any
-	if errors.HasErrors() {
-		return errors
-	}
+	// Verify recursive types that don't depend on poisoned types
+	verified = d.poisonDependents(verified)
+	errors.Add(d.verifyRecursion(verified)...)
 
 	// Successfully register the new types
-	for typeName, newType := range newTypes {
-		d.CompositeTypes[typeName] = newType
-		d.Types[typeName] = newType
+	// that don't depend on poisoned types
+	for _, newType := range d.poisonDependents(verified) {
+		d.CompositeTypes[newType.Name()] = newType.(*CompositeType)
+		d.Types[newType.Name()] = newType
 	}
-	return nil
+	return errors
 }
 
-// RegisterCompositeTypes returns nil if all given composite types
-// were registered in the document model, otherwise returns errors
+// RegisterCompositeTypes registers the given composite types
+// returning the errors of the ones that couldn't be registered
 func (d *Document) RegisterCompositeTypes(
 	newTypes map[string]document.CompositeType,
 ) ModelErrors {
@@ -69,6 +81,9 @@ func (d *Document) RegisterCompositeTypes(
 				Nullable:    field.Nullable,
 				TypeName:    field.Type.Name,
 				IsList:      field.Type.IsList,
+				Examples:    field.Examples,
+				Deprecated:  newDeprecation(field.Deprecated),
+				Index:       field.Index,
 				// Leave Name undefined, it will be set automatically
 				// Leave Type undefined, ref will be set automatically
 			}
@@ -78,6 +93,9 @@ func (d *Document) RegisterCompositeTypes(
 			// Leave TypeName undefined, it will be set automatically
 			Description: compositeType.Description,
 			Metadata:    metadata,
+			Examples:    compositeType.Examples,
+			Deprecated:  newDeprecation(compositeType.Deprecated),
+			Index:       compositeType.Index,
 		}
 	}
 	// Try to register the new composite types
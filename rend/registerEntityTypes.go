@@ -8,49 +8,68 @@ import (
 // It will automatically set the type names,
 // the names and types of the metadata fields and
 // the names and types of the relation metadata fields.
-// It won't register in case of type name collisions returning an error
+// Entity types failing the verification, entity types depending on them
+// and their relation types are poisoned instead of being registered
 func (d *Document) registerEntityTypes(
 	newEntityTypes EntityTypes,
 ) (errors ModelErrors) {
 	forwardDeclared := make(Types, len(newEntityTypes))
 
+	// Fields referencing any of the new entity types or their relation
+	// types are reported as such rather than as undefined types
+	declaredEntityTypes := make(Types, len(newEntityTypes))
+	for entityTypeName, newType := range newEntityTypes {
+		declaredEntityTypes[entityTypeName] = newType
+		for _, relation := range newType.Relations {
+			declaredEntityTypes[relation.TypeName.String()] = relation
+		}
+	}
+
 	// Verify entity types verifying their type names and metadata
 	for entityTypeName, newType := range newEntityTypes {
 		newType.TypeName = entityTypeName
 
-		// Verify type name
-		errors.Add(d.verifyTypeName(
+		declarationLocation := typeLocation(
+			Entity,
 			entityTypeName,
 			"entity type declaration",
-		)...)
-		if errors.HasErrors() {
-			// Don't evaluate further in case of illegal name
-			continue
-		}
+		)
 
-		// Verify type
-		errors.Add(d.verifyType(
-			nil,
-			entityTypeName,
-			"entity type declaration", // error location
-		)...)
-		if errors.HasErrors() {
-			// Don't evaluate further in case of invalid type
+		// Verify type name
+		errs := d.verifyTypeName(Entity, entityTypeName, declarationLocation)
+		if !errs.HasErrors() {
+			errs.Add(d.verifyType(nil, entityTypeName, declarationLocation)...)
+		}
+		if errs.HasErrors() {
+			// Don't evaluate further in case of an invalid type
+			errors.Add(errs...)
+			d.poison(entityTypeName)
 			continue
 		}
 
 		// Verify metadata
-		errors.Add(d.verifyMetadataIntegrity(
-			nil,
-			newType,
-		)...)
+		errs = d.verifyMetadataIntegrity(declaredEntityTypes, newType)
+		if errs.HasErrors() {
+			errors.Add(errs...)
+			d.poison(entityTypeName)
+		}
 
+		// Entity types with invalid metadata are still forward declared
+		// to not report relations to them as undefined
 		forwardDeclared[entityTypeName] = newType
 	}
 
 	// Verify the integrity of all entity relations
-	for _, entityType := range newEntityTypes {
+	for entityTypeName, entityType := range forwardDeclared {
+		entityType := entityType.(*EntityType)
 		for relationName, relation := range entityType.Relations {
+			if relation.TypeName.RelationType == "" ||
+				relation.RelatedTypeName == "" {
+				// The relation is rejected by the structure verification
+				d.poison(entityTypeName)
+				continue
+			}
+
 			// Verify all relations before committing any changes to the model
 			errs := d.verifyRelationIntegrity(
 				forwardDeclared,
@@ -60,6 +79,7 @@ func (d *Document) registerEntityTypes(
 			)
 			if errs.HasErrors() {
 				errors.Add(errs...)
+				d.poison(entityTypeName)
 				continue
 			}
 
@@ -91,33 +111,55 @@ func (d *Document) registerEntityTypes(
 		}
 	}
 
-	// Don't commit any changes to the model if the registration
-	// of new entity types and their relations failed
-	if errors.HasErrors() {
-		return errors
+	// Poison the entity types related to poisoned entity types
+	// and the relation types of all poisoned entity types
+	verified := make([]ComplexType, 0, len(forwardDeclared))
+	for typeName, entityType := range forwardDeclared {
+		if !d.isPoisoned(typeName) {
+			verified = append(verified, entityType.(*EntityType))
+		}
+	}
+	verified = d.poisonDependents(verified)
+	for typeName, entityType := range newEntityTypes {
+		if !d.isPoisoned(typeName) {
+			continue
+		}
+		for _, relationType := range entityType.Relations {
+			d.poison(relationType.TypeName.String())
+		}
 	}
 
-	// Successfully register the new types together with their relations
-	for typeName, newEntityType := range newEntityTypes {
-		d.EntityTypes[typeName] = newEntityType
-		d.Types[typeName] = newEntityType
+	// Successfully register the remaining types together with their relations
+	for _, verifiedType := range verified {
+		newEntityType := verifiedType.(*EntityType)
+		d.EntityTypes[newEntityType.Name()] = newEntityType
+		d.Types[newEntityType.Name()] = newEntityType
 		for _, relationType := range newEntityType.Relations {
 			name := relationType.TypeName.String()
 			d.Relations[name] = relationType
 			d.Types[name] = relationType
 		}
 	}
-	return nil
+	return errors
 }
 
-// RegisterEntityTypes returns nil if all given entity types
-// were registered in the document model, otherwise returns errors
+// RegisterEntityTypes registers the given entity types
+// returning the errors of the ones that couldn't be registered
 func (d *Document) RegisterEntityTypes(
 	newTypes map[string]document.EntityType,
 ) ModelErrors {
+	// Related types are qualified relative to the namespace
+	// of the declaring entity type
+	isDeclared := func(name string) bool {
+		_, isNew := newTypes[name]
+		_, isRegistered := d.Types[name]
+		return isNew || isRegistered || d.poisoned[name]
+	}
+
 	// Prepare entity types for registration
 	newEntityTypes := make(EntityTypes, len(newTypes))
 	for typeName, entityType := range newTypes {
+		namespace, _ := document.SplitTypeName(typeName)
 		// Parse entity metadata
 		metadata := make(Metadata, len(entityType.Metadata))
 		for fieldName, field := range entityType.Metadata {
@@ -126,6 +168,9 @@ func (d *Document) RegisterEntityTypes(
 				Nullable:    field.Nullable,
 				TypeName:    field.Type.Name,
 				IsList:      field.Type.IsList,
+				Examples:    field.Examples,
+				Deprecated:  newDeprecation(field.Deprecated),
+				Index:       field.Index,
 				// Leave Name undefined, it will be set automatically
 				// Leave Type undefined, ref will be set automatically
 			}
@@ -145,11 +190,20 @@ func (d *Document) RegisterEntityTypes(
 					Nullable:    field.Nullable,
 					TypeName:    field.Type.Name,
 					IsList:      field.Type.IsList,
+					Examples:    field.Examples,
+					Deprecated:  newDeprecation(field.Deprecated),
+					Index:       field.Index,
 					// Leave Name undefined, it will be set automatically
 					// Leave Type undefined, ref will be set automatically
 				}
 			}
 
+			relatedTypeName := document.QualifyTypeName(
+				namespace,
+				relation.RelatedType,
+				isDeclared,
+			)
+
 			var sourceTypeName, targetTypeName string
 			var relationTypeName EntityRelationTypeName
 			if relation.Direction == document.OutboundRelation {
@@ -157,18 +211,18 @@ func (d *Document) RegisterEntityTypes(
 				relationTypeName = EntityRelationTypeName{
 					SourceType:   typeName,
 					RelationType: relation.Type,
-					TargetType:   relation.RelatedType,
+					TargetType:   relatedTypeName,
 				}
 				sourceTypeName = typeName
-				targetTypeName = relation.RelatedType
+				targetTypeName = relatedTypeName
 			} else {
 				// (this type) <- relatedType
 				relationTypeName = EntityRelationTypeName{
-					SourceType:   relation.RelatedType,
+					SourceType:   relatedTypeName,
 					RelationType: relation.Type,
 					TargetType:   typeName,
 				}
-				sourceTypeName = relation.RelatedType
+				sourceTypeName = relatedTypeName
 				targetTypeName = typeName
 			}
 			relations[relationName] = &EntityRelationType{
@@ -177,8 +231,12 @@ func (d *Document) RegisterEntityTypes(
 				Direction:       relation.Direction,
 				SourceTypeName:  sourceTypeName,
 				TargetTypeName:  targetTypeName,
-				RelatedTypeName: relation.RelatedType,
+				RelatedTypeName: relatedTypeName,
 				TypeName:        relationTypeName,
+				Examples:        relation.Examples,
+				Deprecated:      newDeprecation(relation.Deprecated),
+				RelationName:    relationName,
+				Index:           relation.Index,
 				// Leave SourceType undefined, ref will be set automatically
 				// Leave TargetType undefined, ref will be set automatically
 				// Leave RelatedType undefined, ref will be set automatically
@@ -191,6 +249,9 @@ func (d *Document) RegisterEntityTypes(
 			Description: entityType.Description,
 			Metadata:    metadata,
 			Relations:   relations,
+			Examples:    entityType.Examples,
+			Deprecated:  newDeprecation(entityType.Deprecated),
+			Index:       entityType.Index,
 		}
 	}
 	// Try to register the new entity types
@@ -1,37 +1,50 @@
 package rend
 
+import (
+	"github.com/romshark/TypeBook/document"
+)
+
 // registerEnumerationType registers a new enumeration type
 func (d *Document) registerEnumerationType(
 	forwardDeclared Types,
-	typeName,
-	description string,
-	values map[string]string,
+	newType *EnumerationType,
 ) (errors ModelErrors) {
+	declarationLocation := typeLocation(
+		Enumeration,
+		newType.TypeName,
+		"enumeration type declaration",
+	)
+
 	// Verify type name
 	errors.Add(d.verifyTypeName(
-		typeName,
-		"enumeration type declaration",
+		Enumeration,
+		newType.TypeName,
+		declarationLocation,
 	)...)
 	if errors.HasErrors() {
 		// Don't evaluate further in case of illegal name
+		d.poison(newType.TypeName)
 		return errors
 	}
 
 	// Verify type
 	errors.Add(d.verifyType(
 		forwardDeclared,
-		typeName,
-		"enumeration type declaration", // error location
+		newType.TypeName,
+		declarationLocation,
 	)...)
 	if errors.HasErrors() {
 		// Don't evaluate further in case of invalid type
+		d.poison(newType.TypeName)
 		return errors
 	}
 
-	newType := &EnumerationType{
-		TypeName:    typeName,
-		Description: description,
-		Values:      values,
+	// Verify values
+	errors.Add(d.verifyEnumerationValues(newType)...)
+	if errors.HasErrors() {
+		// Don't register types with ambiguous values
+		d.poison(newType.TypeName)
+		return errors
 	}
 
 	// Successfully register the new type
@@ -42,15 +55,29 @@ func (d *Document) registerEnumerationType(
 
 // RegisterEnumerationType registers a new enumeration type
 func (d *Document) RegisterEnumerationType(
-	typeName,
-	description string,
-	values map[string]string,
+	typeName string,
+	enumerationType document.EnumerationType,
 ) (errors ModelErrors) {
 	// Copy the key-value pairs
-	valuesCopy := make(EnumerationValues, len(values))
-	for item, val := range values {
-		valuesCopy[item] = val
+	valuesCopy := make(EnumerationValues, len(enumerationType.Values))
+	for item, val := range enumerationType.Values {
+		valuesCopy[item] = EnumerationValue{
+			Name:        item,
+			Value:       val.Value,
+			Kind:        val.Kind,
+			Description: val.Description,
+			Aliases:     val.Aliases,
+			Deprecated:  newDeprecation(val.Deprecated),
+			Index:       val.Index,
+		}
 	}
 
-	return d.registerEnumerationType(nil, typeName, description, valuesCopy)
+	return d.registerEnumerationType(nil, &EnumerationType{
+		TypeName:    typeName,
+		Description: enumerationType.Description,
+		Values:      valuesCopy,
+		Examples:    enumerationType.Examples,
+		Deprecated:  newDeprecation(enumerationType.Deprecated),
+		Index:       enumerationType.Index,
+	})
 }
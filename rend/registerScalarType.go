@@ -1,36 +1,61 @@
 package rend
 
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/romshark/TypeBook/document"
+)
+
 // registerScalarType registers a new scalar type
+// verifying and compiling the given constraints
 func (d *Document) registerScalarType(
 	forwardDeclared Types,
-	typeName,
-	description string,
+	newType *ScalarType,
+	constraints document.ScalarConstraints,
 ) (errors ModelErrors) {
+	declarationLocation := typeLocation(
+		Scalar,
+		newType.TypeName,
+		"scalar type declaration",
+	)
+
 	// Verify type name
 	errors.Add(d.verifyTypeName(
-		typeName,
-		"scalar type declaration",
+		Scalar,
+		newType.TypeName,
+		declarationLocation,
 	)...)
 	if errors.HasErrors() {
 		// Don't evaluate further in case of illegal name
+		d.poison(newType.TypeName)
 		return errors
 	}
 
 	// verify type
 	errors.Add(d.verifyType(
 		forwardDeclared,
-		typeName,
-		"scalar type declaration", // error location
+		newType.TypeName,
+		declarationLocation,
 	)...)
 	if errors.HasErrors() {
 		// Don't evaluate further in case of invalid type
+		d.poison(newType.TypeName)
 		return errors
 	}
 
-	newType := &ScalarType{
-		TypeName:    typeName,
-		Description: description,
+	// Verify constraints
+	newConstraints, errs := verifyScalarConstraints(
+		newType.TypeName,
+		newType.Kind,
+		constraints,
+	)
+	if errs.HasErrors() {
+		// Don't register scalar types with invalid constraints
+		d.poison(newType.TypeName)
+		return errs
 	}
+	newType.Constraints = newConstraints
 
 	// Successfully register the new type
 	d.ScalarTypes[newType.TypeName] = newType
@@ -38,10 +63,83 @@ func (d *Document) registerScalarType(
 	return nil
 }
 
+// verifyScalarConstraints returns errors if the given constraints
+// contradict each other or don't apply to the given scalar kind,
+// otherwise returns the compiled constraints
+func verifyScalarConstraints(
+	typeName string,
+	kind document.ScalarKind,
+	constraints document.ScalarConstraints,
+) (compiled ScalarConstraints, errors ModelErrors) {
+	location := Location{
+		Description: fmt.Sprintf("constraints of scalar type '%s'", typeName),
+		Path:        appendPath(typePath(Scalar, typeName), "constraints"),
+	}
+
+	isTextual := kind == document.AnyKind || kind == document.StringKind
+	isNumeric := kind == document.AnyKind ||
+		kind == document.NumberKind ||
+		kind == document.IntegerKind
+
+	if constraints.Pattern != "" {
+		pattern, err := regexp.Compile(constraints.Pattern)
+		if err != nil {
+			errors.AddErrInvalidScalarConstraint(
+				fmt.Sprintf("invalid pattern: %s", err),
+				location,
+			)
+		}
+		compiled.Pattern = pattern
+	}
+	if (constraints.Pattern != "" ||
+		constraints.MinLength != nil ||
+		constraints.MaxLength != nil) && !isTextual {
+		errors.AddErrInvalidScalarConstraint(
+			fmt.Sprintf("textual constraints on a %s scalar", kind),
+			location,
+		)
+	}
+	if (constraints.Minimum != nil || constraints.Maximum != nil) &&
+		!isNumeric {
+		errors.AddErrInvalidScalarConstraint(
+			fmt.Sprintf("numeric constraints on a %s scalar", kind),
+			location,
+		)
+	}
+	if constraints.Minimum != nil && constraints.Maximum != nil &&
+		*constraints.Minimum > *constraints.Maximum {
+		errors.AddErrInvalidScalarConstraint(
+			"minimum exceeds maximum",
+			location,
+		)
+	}
+	if constraints.MinLength != nil && constraints.MaxLength != nil &&
+		*constraints.MinLength > *constraints.MaxLength {
+		errors.AddErrInvalidScalarConstraint(
+			"min length exceeds max length",
+			location,
+		)
+	}
+
+	compiled.Minimum = constraints.Minimum
+	compiled.Maximum = constraints.Maximum
+	compiled.MinLength = constraints.MinLength
+	compiled.MaxLength = constraints.MaxLength
+	return compiled, errors
+}
+
 // RegisterScalarType registers a new scalar type
 func (d *Document) RegisterScalarType(
-	typeName,
-	description string,
+	typeName string,
+	scalarType document.ScalarType,
 ) ModelErrors {
-	return d.registerScalarType(nil, typeName, description)
+	return d.registerScalarType(nil, &ScalarType{
+		TypeName:    typeName,
+		Description: scalarType.Description,
+		Kind:        scalarType.Kind,
+		Examples:    scalarType.Examples,
+		Deprecated:  newDeprecation(scalarType.Deprecated),
+		Index:       scalarType.Index,
+		// Leave Constraints undefined, they will be set automatically
+	}, scalarType.Constraints)
 }
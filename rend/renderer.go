@@ -2,8 +2,11 @@ package rend
 
 import (
 	"fmt"
+	"io/fs"
 	"text/template"
 	"time"
+
+	templates "github.com/romshark/TypeBook/template"
 )
 
 const rendererVersion = "0.1"
@@ -12,18 +15,32 @@ type Renderer struct {
 	template *template.Template
 }
 
-// New creates a new document renderer
+// templateFiles lists the template files in the order they're parsed in
+var templateFiles = []string{
+	"index.html",
+	"search.html",
+	"table-of-contents.html",
+	"scalar-types.html",
+	"prelude.html",
+	"enumeration-types.html",
+	"composite-types.html",
+	"entity-types.html",
+	"examples.html",
+	"deprecation.html",
+	"deprecated.html",
+}
+
+// New creates a new document renderer using the built-in templates
 func New() (*Renderer, *InitStats, error) {
+	return NewFromFS(templates.Files)
+}
+
+// NewFromFS creates a new document renderer using the templates
+// of the given file system
+func NewFromFS(fsys fs.FS) (*Renderer, *InitStats, error) {
 	// Compile HTML template
 	startCompileTemplate := time.Now()
-	t, err := template.ParseFiles([]string{
-		"./template/index.html",
-		"./template/table-of-contents.html",
-		"./template/scalar-types.html",
-		"./template/enumeration-types.html",
-		"./template/composite-types.html",
-		"./template/entity-types.html",
-	}...)
+	t, err := template.ParseFS(fsys, templateFiles...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("couldn't parse template: %s", err)
 	}
@@ -1,9 +1,46 @@
 package rend
 
+import (
+	"regexp"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// ScalarConstraints restricts the values a scalar type accepts.
+// Nil constraints are not enforced
+type ScalarConstraints struct {
+	Pattern   *regexp.Regexp
+	Minimum   *float64
+	Maximum   *float64
+	MinLength *uint32
+	MaxLength *uint32
+}
+
 // ScalarType represents a distinct scalar type
 type ScalarType struct {
 	TypeName    string
 	Description string
+
+	// Kind defines the kind of values this scalar type accepts
+	Kind document.ScalarKind
+
+	// Constraints restricts the values this scalar type accepts
+	Constraints ScalarConstraints
+
+	// Examples lists example values of this type
+	Examples []interface{}
+
+	Deprecated *Deprecation
+
+	// Index is the declaration index of the type
+	Index int
+
+	// Prelude is true for built-in types registered by the prelude
+	Prelude bool
+
+	// Mappings maps code generator names to the native types
+	// the values of this type are mapped to by default
+	Mappings map[string]string
 }
 
 // TypeCategory implements the AbstractType interface
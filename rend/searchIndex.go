@@ -0,0 +1,124 @@
+package rend
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// SearchEntry represents a searchable item of the rendered document
+type SearchEntry struct {
+	// Name is the qualified name of the item such as "Movie.title"
+	Name string `json:"n"`
+
+	// Kind describes the kind of the item such as "field"
+	Kind string `json:"k"`
+
+	// Anchor is the name of the anchor the item is rendered at
+	Anchor string `json:"a"`
+
+	// Text is the searchable text describing the item
+	Text string `json:"t,omitempty"`
+}
+
+// searchText returns the given texts joined into a single line
+func searchText(texts ...string) string {
+	return strings.Join(strings.Fields(strings.Join(texts, " ")), " ")
+}
+
+// addFields adds search entries for the fields of the given type
+func (d *Document) addFields(
+	entries []SearchEntry,
+	t ComplexType,
+	anchor string,
+) []SearchEntry {
+	for _, field := range d.OrderedFields(t) {
+		entries = append(entries, SearchEntry{
+			Name:   anchor + "." + field.Name,
+			Kind:   "field",
+			Anchor: anchor,
+			Text:   searchText(field.TypeName, field.Description),
+		})
+	}
+	return entries
+}
+
+// SearchIndex returns the search entries of the type names,
+// field names, enumeration items, relation names and descriptions
+// of the document in the document order
+func (d *Document) SearchIndex() []SearchEntry {
+	entries := make([]SearchEntry, 0, len(d.Types))
+	for _, t := range d.OrderedScalarTypes() {
+		kind := "scalar type"
+		if t.Prelude {
+			kind = "prelude type"
+		}
+		entries = append(entries, SearchEntry{
+			Name:   t.TypeName,
+			Kind:   kind,
+			Anchor: t.TypeName,
+			Text:   searchText(t.Description),
+		})
+	}
+	for _, t := range d.OrderedEnumerationTypes() {
+		entries = append(entries, SearchEntry{
+			Name:   t.TypeName,
+			Kind:   "enumeration type",
+			Anchor: t.TypeName,
+			Text:   searchText(t.Description),
+		})
+		for _, value := range d.OrderedEnumerationValues(t) {
+			entries = append(entries, SearchEntry{
+				Name:   t.TypeName + "." + value.Name,
+				Kind:   "enumeration item",
+				Anchor: t.TypeName,
+				Text: searchText(
+					value.Value,
+					strings.Join(value.Aliases, " "),
+					value.Description,
+				),
+			})
+		}
+	}
+	for _, t := range d.OrderedCompositeTypes() {
+		entries = append(entries, SearchEntry{
+			Name:   t.TypeName,
+			Kind:   "composite type",
+			Anchor: t.TypeName,
+			Text:   searchText(t.Description),
+		})
+		entries = d.addFields(entries, t, t.TypeName)
+	}
+	for _, t := range d.OrderedEntityTypes() {
+		entries = append(entries, SearchEntry{
+			Name:   t.TypeName,
+			Kind:   "entity type",
+			Anchor: t.TypeName,
+			Text:   searchText(t.Description),
+		})
+		entries = d.addFields(entries, t, t.TypeName)
+		for _, relation := range d.OrderedRelations(t) {
+			entries = append(entries, SearchEntry{
+				Name:   t.TypeName + "." + relation.RelationName,
+				Kind:   "relation",
+				Anchor: t.TypeName,
+				Text: searchText(
+					relation.TypeName.RelationType,
+					relation.RelatedTypeName,
+					relation.Description,
+				),
+			})
+		}
+	}
+	return entries
+}
+
+// SearchIndexJSON returns the JSON encoded search index.
+// Markup characters are escaped for the index
+// to be safely embeddable in a script element
+func (d *Document) SearchIndexJSON() (string, error) {
+	encoded, err := json.Marshal(d.SearchIndex())
+	if err != nil {
+		return "", err
+	}
+	return string(encoded), nil
+}
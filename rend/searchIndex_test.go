@@ -0,0 +1,54 @@
+package rend
+
+import "testing"
+
+// TestSearchIndex verifies that the search index covers
+// the types, fields and enumeration items of the document
+func TestSearchIndex(t *testing.T) {
+	model, errs := newModel(t, "scalar types:\n"+
+		"  Title:\n"+
+		"    kind: string\n"+
+		"enumeration types:\n"+
+		"  Genre:\n"+
+		"    values:\n"+
+		"      Drama:\n"+
+		"        description: Serious narratives\n"+
+		"composite types:\n"+
+		"  Movie:\n"+
+		"    description: A motion picture\n"+
+		"    meta:\n"+
+		"      title:\n"+
+		"        type: Title\n")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors: %#v", errs)
+	}
+	expected := map[string]SearchEntry{
+		"Title": {Name: "Title", Kind: "scalar type", Anchor: "Title"},
+		"Genre.Drama": {
+			Name:   "Genre.Drama",
+			Kind:   "enumeration item",
+			Anchor: "Genre",
+			Text:   "Serious narratives",
+		},
+		"Movie": {
+			Name:   "Movie",
+			Kind:   "composite type",
+			Anchor: "Movie",
+			Text:   "A motion picture",
+		},
+		"Movie.title": {
+			Name:   "Movie.title",
+			Kind:   "field",
+			Anchor: "Movie",
+			Text:   "Title",
+		},
+	}
+	for _, entry := range model.SearchIndex() {
+		if expected[entry.Name] == entry {
+			delete(expected, entry.Name)
+		}
+	}
+	if len(expected) > 0 {
+		t.Fatalf("missing search entries: %v", expected)
+	}
+}
@@ -0,0 +1,283 @@
+package rend
+
+import (
+	"strings"
+)
+
+// siteDirectories maps the type categories
+// to the site directories containing their pages
+var siteDirectories = map[TypeCategory]string{
+	Scalar:      "scalar-types",
+	Enumeration: "enumeration-types",
+	Composite:   "composite-types",
+	Entity:      "entity-types",
+	Relation:    "relations",
+}
+
+// siteCategoryTitles maps the type categories
+// to the titles of their index pages
+var siteCategoryTitles = map[TypeCategory]string{
+	Scalar:      "Scalar Types",
+	Enumeration: "Enumeration Types",
+	Composite:   "Composite Types",
+	Entity:      "Entity Types",
+	Relation:    "Relations",
+}
+
+// siteCategories lists the type categories in the order
+// their index pages are listed in
+var siteCategories = []TypeCategory{
+	Scalar,
+	Enumeration,
+	Composite,
+	Entity,
+	Relation,
+}
+
+// siteTypeTemplates maps the type categories
+// to the templates of their type pages
+var siteTypeTemplates = map[TypeCategory]string{
+	Scalar:      "scalar-type.html",
+	Enumeration: "enumeration-type.html",
+	Composite:   "composite-type.html",
+	Entity:      "entity-type.html",
+	Relation:    "relation-type.html",
+}
+
+// Breadcrumb represents a step of the path leading to a site page
+type Breadcrumb struct {
+	Name string
+
+	// Path is the path of the page the step links to,
+	// it's empty for steps without a page such as namespaces
+	Path string
+}
+
+// SiteCategory represents the index page of a type category
+type SiteCategory struct {
+	Category TypeCategory
+	Title    string
+	Path     string
+	Total    int
+}
+
+// SiteDeprecation represents a deprecation notice rendered on a site page
+type SiteDeprecation struct {
+	*Deprecation
+	Page *SitePage
+}
+
+// SitePage represents a page of a multi-page static site
+type SitePage struct {
+	*Document
+
+	// Path is the slash separated path of the page within the site
+	Path string
+
+	Title       string
+	Breadcrumbs []Breadcrumb
+
+	// Category is the type category of category index and type pages
+	Category TypeCategory
+
+	// Type is the type described by type pages, it's nil for other pages
+	Type AbstractType
+
+	// template is the name of the template the page is rendered by
+	template string
+}
+
+// categoryIndexPath returns the path of the index page
+// of the given type category
+func categoryIndexPath(category TypeCategory) string {
+	return siteDirectories[category] + "/index.html"
+}
+
+// typePagePath returns the path of the page of the given type
+func typePagePath(t AbstractType) string {
+	return siteDirectories[t.TypeCategory()] + "/" + t.Name() + ".html"
+}
+
+// Root returns the relative path leading from the page to the site root
+// keeping links working from any location the site is served from
+func (p *SitePage) Root() string {
+	return strings.Repeat("../", strings.Count(p.Path, "/"))
+}
+
+// Name returns the name of the page in its breadcrumbs,
+// type pages are named by the local names of their types
+func (p *SitePage) Name() string {
+	if p.Type == nil {
+		return p.Title
+	}
+	return LocalName(p.Type)
+}
+
+// Link returns the relative link to the page of the given type
+func (p *SitePage) Link(t AbstractType) string {
+	return p.Root() + typePagePath(t)
+}
+
+// LinkName returns the relative link to the page of the type
+// of the given name or an empty string if it's undefined
+func (p *SitePage) LinkName(typeName string) string {
+	t, isDefined := p.Types[typeName]
+	if !isDefined {
+		return ""
+	}
+	return p.Link(t)
+}
+
+// Categories returns the index pages of the type categories
+func (p *SitePage) Categories() []SiteCategory {
+	totals := map[TypeCategory]int{
+		Scalar:      len(p.DeclaredScalarTypes()),
+		Enumeration: len(p.EnumerationTypes),
+		Composite:   len(p.CompositeTypes),
+		Entity:      len(p.EntityTypes),
+		Relation:    len(p.Relations),
+	}
+	categories := make([]SiteCategory, len(siteCategories))
+	for i, category := range siteCategories {
+		categories[i] = SiteCategory{
+			Category: category,
+			Title:    siteCategoryTitles[category],
+			Path:     categoryIndexPath(category),
+			Total:    totals[category],
+		}
+	}
+	return categories
+}
+
+// Listing returns the types listed by the category index page
+// grouped by their namespaces
+func (p *SitePage) Listing() []NamespaceGroup {
+	switch p.Category {
+	case Scalar:
+		return p.ScalarTypeNamespaces()
+	case Enumeration:
+		return p.EnumerationTypeNamespaces()
+	case Composite:
+		return p.CompositeTypeNamespaces()
+	case Entity:
+		return p.EntityTypeNamespaces()
+	case Relation:
+		return p.RelationTypeNamespaces()
+	}
+	return nil
+}
+
+// Deprecation returns the given deprecation notice
+// bound to the page for its links to be relative to it
+func (p *SitePage) Deprecation(deprecation *Deprecation) SiteDeprecation {
+	return SiteDeprecation{Deprecation: deprecation, Page: p}
+}
+
+// UsedBy returns the other composite-, entity- and relation types
+// referencing the type of the page by a field or a relation
+func (p *SitePage) UsedBy() []AbstractType {
+	users := make([]AbstractType, 0)
+	if p.Type == nil {
+		return users
+	}
+	uses := func(t ComplexType) bool {
+		if AbstractType(t) == p.Type {
+			return false
+		}
+		for _, field := range t.MetaInformation() {
+			if field.Type == p.Type {
+				return true
+			}
+		}
+		if entity, isEntity := t.(*EntityType); isEntity {
+			for _, relation := range entity.Relations {
+				if relation.RelatedType == p.Type {
+					return true
+				}
+			}
+		}
+		return false
+	}
+	for _, t := range p.OrderedCompositeTypes() {
+		if uses(t) {
+			users = append(users, t)
+		}
+	}
+	for _, t := range p.OrderedEntityTypes() {
+		if uses(t) {
+			users = append(users, t)
+		}
+	}
+	for _, group := range p.RelationTypeNamespaces() {
+		for _, t := range group.Types {
+			if uses(t.(ComplexType)) {
+				users = append(users, t)
+			}
+		}
+	}
+	return users
+}
+
+// SitePages returns the pages of the multi-page static site
+// of the document in the order they're rendered in
+func (d *Document) SitePages() []*SitePage {
+	home := Breadcrumb{Name: d.Metadata.Title, Path: "index.html"}
+	pages := []*SitePage{{
+		Document: d,
+		Path:     "index.html",
+		Title:    d.Metadata.Title,
+		template: "index.html",
+	}}
+
+	for _, category := range siteCategories {
+		categoryCrumb := Breadcrumb{
+			Name: siteCategoryTitles[category],
+			Path: categoryIndexPath(category),
+		}
+		page := &SitePage{
+			Document:    d,
+			Path:        categoryCrumb.Path,
+			Title:       categoryCrumb.Name,
+			Breadcrumbs: []Breadcrumb{home},
+			Category:    category,
+			template:    "category.html",
+		}
+		pages = append(pages, page)
+
+		types := make([]AbstractType, 0)
+		for _, group := range page.Listing() {
+			types = append(types, group.Types...)
+		}
+		if category == Scalar {
+			for _, t := range d.PreludeTypes() {
+				types = append(types, t)
+			}
+		}
+		for _, t := range types {
+			breadcrumbs := []Breadcrumb{home, categoryCrumb}
+			if namespace := Namespace(t); namespace != "" {
+				breadcrumbs = append(breadcrumbs, Breadcrumb{Name: namespace})
+			}
+			pages = append(pages, &SitePage{
+				Document:    d,
+				Path:        typePagePath(t),
+				Title:       t.Name(),
+				Breadcrumbs: breadcrumbs,
+				Category:    category,
+				Type:        t,
+				template:    siteTypeTemplates[category],
+			})
+		}
+	}
+
+	if len(d.DeprecatedItems()) > 0 {
+		pages = append(pages, &SitePage{
+			Document:    d,
+			Path:        "deprecated.html",
+			Title:       "Deprecated",
+			Breadcrumbs: []Breadcrumb{home},
+			template:    "deprecated.html",
+		})
+	}
+	return pages
+}
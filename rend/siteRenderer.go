@@ -0,0 +1,114 @@
+package rend
+
+import (
+	"fmt"
+	"io/fs"
+	"text/template"
+	"time"
+
+	templates "github.com/romshark/TypeBook/template"
+)
+
+// siteStylesheet is the path of the stylesheet shared by the site pages
+const siteStylesheet = "site/style.css"
+
+// siteTemplateFiles lists the template files of the site
+// in the order they're parsed in
+var siteTemplateFiles = []string{
+	"site/index.html",
+	"site/category.html",
+	"site/scalar-type.html",
+	"site/enumeration-type.html",
+	"site/composite-type.html",
+	"site/entity-type.html",
+	"site/relation-type.html",
+	"site/deprecated.html",
+	"site/header.html",
+	"site/footer.html",
+	"site/fields.html",
+	"site/used-by.html",
+	"site/deprecation.html",
+	"examples.html",
+}
+
+// SiteRenderer renders document models to multi-page static sites
+type SiteRenderer struct {
+	template   *template.Template
+	stylesheet []byte
+}
+
+// NewSite creates a new site renderer using the built-in templates
+func NewSite() (*SiteRenderer, *InitStats, error) {
+	return NewSiteFromFS(templates.Files)
+}
+
+// NewSiteFromFS creates a new site renderer using the templates
+// and the stylesheet of the given file system
+func NewSiteFromFS(fsys fs.FS) (*SiteRenderer, *InitStats, error) {
+	startCompileTemplate := time.Now()
+	t, err := template.ParseFS(fsys, siteTemplateFiles...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't parse template: %s", err)
+	}
+	stylesheet, err := fs.ReadFile(fsys, siteStylesheet)
+	if err != nil {
+		return nil, nil, fmt.Errorf("couldn't read stylesheet: %s", err)
+	}
+	compileTemplateDur := time.Since(startCompileTemplate)
+
+	return &SiteRenderer{
+		template:   t,
+		stylesheet: stylesheet,
+	}, &InitStats{
+		CompileTemplateDur: compileTemplateDur,
+	}, nil
+}
+
+// Render renders the pages and the stylesheet of the given document model
+// to the given site writer. The pages link each other relatively
+func (r *SiteRenderer) Render(
+	model *Document,
+	site SiteWriter,
+) (*RenderingStats, error) {
+	startRendering := time.Now()
+
+	file, err := site.Create("style.css")
+	if err != nil {
+		return nil, fmt.Errorf("couldn't create stylesheet: %s", err)
+	}
+	if _, err := file.Write(r.stylesheet); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("couldn't write stylesheet: %s", err)
+	}
+	if err := file.Close(); err != nil {
+		return nil, fmt.Errorf("couldn't write stylesheet: %s", err)
+	}
+
+	for _, page := range model.SitePages() {
+		file, err := site.Create(page.Path)
+		if err != nil {
+			return nil, fmt.Errorf("couldn't create page %s: %s", page.Path, err)
+		}
+		if err := r.template.ExecuteTemplate(
+			file,
+			page.template,
+			page,
+		); err != nil {
+			file.Close()
+			return nil, fmt.Errorf(
+				"couldn't render page %s: %s",
+				page.Path,
+				err,
+			)
+		}
+		if err := file.Close(); err != nil {
+			return nil, fmt.Errorf("couldn't write page %s: %s", page.Path, err)
+		}
+	}
+
+	renderingDur := time.Since(startRendering)
+
+	return &RenderingStats{
+		RenderingDur: renderingDur,
+	}, nil
+}
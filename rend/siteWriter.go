@@ -0,0 +1,72 @@
+package rend
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// SiteWriter creates the files of a rendered site
+type SiteWriter interface {
+	// Create creates the file at the given slash separated path
+	// relative to the site root
+	Create(path string) (io.WriteCloser, error)
+}
+
+// directoryWriter writes the files of a site to a directory
+type directoryWriter struct {
+	dir string
+}
+
+// NewDirectoryWriter creates a new site writer writing the files
+// of a site to the given directory creating it if necessary
+func NewDirectoryWriter(dir string) SiteWriter {
+	return directoryWriter{dir: dir}
+}
+
+// Create implements the SiteWriter interface
+func (w directoryWriter) Create(path string) (io.WriteCloser, error) {
+	filePath := filepath.Join(w.dir, filepath.FromSlash(path))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return nil, err
+	}
+	return os.Create(filePath)
+}
+
+// ZipWriter writes the files of a site to a zip archive
+type ZipWriter struct {
+	archive *zip.Writer
+}
+
+// NewZipWriter creates a new site writer writing the files of a site
+// to a zip archive written to the given writer.
+// The archive must be closed after rendering
+func NewZipWriter(w io.Writer) *ZipWriter {
+	return &ZipWriter{archive: zip.NewWriter(w)}
+}
+
+// zipFile represents a file of a zip archive
+// that's completed by creating the next file
+type zipFile struct {
+	io.Writer
+}
+
+// Close implements the io.Closer interface
+func (zipFile) Close() error {
+	return nil
+}
+
+// Create implements the SiteWriter interface
+func (w *ZipWriter) Create(path string) (io.WriteCloser, error) {
+	file, err := w.archive.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return zipFile{file}, nil
+}
+
+// Close finishes writing the zip archive
+func (w *ZipWriter) Close() error {
+	return w.archive.Close()
+}
@@ -0,0 +1,83 @@
+package rend
+
+import (
+	"sort"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// IsFieldType returns true if the given type can be used
+// as the type of a metadata field, otherwise returns false
+func IsFieldType(t AbstractType) bool {
+	switch t.(type) {
+	case *EntityType, *EntityRelationType:
+		return false
+	}
+	return true
+}
+
+// isEntityType returns true if the given type is an entity type,
+// otherwise returns false
+func isEntityType(t AbstractType) bool {
+	_, isEntity := t.(*EntityType)
+	return isEntity
+}
+
+// SuggestType returns the name of the registered type most similar
+// to the given type name that's accepted by the given filter.
+// Returns an empty string if there's no similar type
+func (d *Document) SuggestType(
+	typeName string,
+	accepts func(AbstractType) bool,
+) string {
+	return d.suggestType(nil, typeName, accepts)
+}
+
+// suggestType returns the name of the registered or forward-declared
+// type most similar to the given type name, see SuggestType
+func (d *Document) suggestType(
+	forwardDeclared Types,
+	typeName string,
+	accepts func(AbstractType) bool,
+) string {
+	candidates := make([]string, 0, len(d.Types)+len(forwardDeclared))
+	for _, types := range []Types{d.Types, forwardDeclared} {
+		for name, t := range types {
+			if name != typeName && (accepts == nil || accepts(t)) {
+				candidates = append(candidates, name)
+			}
+		}
+	}
+	// Resolve equally similar candidates deterministically
+	sort.Strings(candidates)
+	return document.Suggest(typeName, candidates)
+}
+
+// suggestEntityType returns the name of the declared entity type
+// most similar to the given type name, including the entity types
+// that aren't registered yet
+func (d *Document) suggestEntityType(
+	forwardDeclared Types,
+	typeName string,
+) string {
+	declared := make(map[string]bool, len(d.declaredEntityTypes))
+	for name := range d.declaredEntityTypes {
+		declared[name] = true
+	}
+	for _, types := range []Types{d.Types, forwardDeclared} {
+		for name, t := range types {
+			if isEntityType(t) {
+				declared[name] = true
+			}
+		}
+	}
+	candidates := make([]string, 0, len(declared))
+	for name := range declared {
+		if name != typeName {
+			candidates = append(candidates, name)
+		}
+	}
+	// Resolve equally similar candidates deterministically
+	sort.Strings(candidates)
+	return document.Suggest(typeName, candidates)
+}
@@ -0,0 +1,65 @@
+package rend
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestSuggestType verifies that misspelled type names
+// are reported with fix-its replacing them
+func TestSuggestType(t *testing.T) {
+	_, errs := newModel(t, "scalar types:\n"+
+		"  Text:\n"+
+		"    kind: string\n"+
+		"composite types:\n"+
+		"  Point:\n"+
+		"    meta:\n"+
+		"      label:\n"+
+		"        type: text\n")
+	if len(errs) != 1 || errs[0].Code != ErrUndefinedType {
+		t.Fatalf("expected 1 undefined type error, got %#v", errs)
+	}
+	fixes := errs[0].FixIts
+	if len(fixes) != 1 {
+		t.Fatalf("expected 1 fix, got %d", len(fixes))
+	}
+	if fixes[0].Original != "text" ||
+		fixes[0].Replacement != "Text" ||
+		!reflect.DeepEqual(fixes[0].Location.Path, []string{
+			"composite types", "Point", "meta", "label", "type",
+		}) {
+		t.Fatalf("unexpected fix: %#v", fixes[0])
+	}
+}
+
+// TestSuggestTypeEntityField verifies that entity types used
+// as the field types of composite types are reported as such
+// even though composite types are registered before entity types
+func TestSuggestTypeEntityField(t *testing.T) {
+	_, errs := newModel(t, "scalar types:\n"+
+		"  String:\n"+
+		"    kind: string\n"+
+		"composite types:\n"+
+		"  Actors:\n"+
+		"    meta:\n"+
+		"      name:\n"+
+		"        type: String\n"+
+		"  Cast:\n"+
+		"    meta:\n"+
+		"      lead:\n"+
+		"        type: Actor\n"+
+		"entity types:\n"+
+		"  Actor:\n"+
+		"    meta:\n"+
+		"      name:\n"+
+		"        type: String\n")
+	if len(errs) != 1 || errs[0].Code != ErrEntityNesting {
+		t.Fatalf("expected 1 entity nesting error, got %#v", errs)
+	}
+	fixes := errs[0].FixIts
+	if len(fixes) != 1 ||
+		fixes[0].Original != "Actor" ||
+		fixes[0].Replacement != "Actors" {
+		t.Fatalf("unexpected fixes: %#v", fixes)
+	}
+}
@@ -13,8 +13,7 @@ type AbstractType interface {
 
 // ComplexType represents either a composite-, entity- or relation type
 type ComplexType interface {
-	// Name returns the type name
-	Name() string
+	AbstractType
 
 	// MetaInformation returns the typed metadata fields
 	MetaInformation() Metadata
@@ -37,6 +36,14 @@ type TypedField struct {
 	// Type references the type of this field
 	Type     AbstractType
 	TypeName string
+
+	// Examples lists example values of this field
+	Examples []interface{}
+
+	Deprecated *Deprecation
+
+	// Index is the declaration index of the field
+	Index int
 }
 
 // Metadata maps the field names to a metadata field
@@ -51,8 +58,11 @@ type DocumentMetadata struct {
 	Author          string
 	Version         string
 	Description     string
-	Build           time.Time
 	RendererVersion string
+
+	// Build is the build time of the document,
+	// it's zero if the build time is omitted
+	Build time.Time
 }
 
 // ScalarTypes maps type names to distinct scalar types
@@ -82,6 +92,20 @@ type Document struct {
 	EntityTypes      EntityTypes
 	Relations        EntityRelationTypes
 	Types            Types
+
+	// Order defines the order types, fields, relations
+	// and enumeration values are listed in
+	Order Order
+
+	// naming defines the naming rules of type names
+	naming namingRules
+
+	// poisoned marks the names of the types that failed the verification
+	poisoned map[string]bool
+
+	// declaredEntityTypes marks the names of the entity types declared
+	// by the document, including the ones not yet registered
+	declaredEntityTypes map[string]bool
 }
 
 func NewDocument(
@@ -91,13 +115,18 @@ func NewDocument(
 	version string,
 	description string,
 ) (*Document, error) {
+	var build time.Time
+	if !currentTime.IsZero() {
+		build = time.Unix(currentTime.Unix(), 0).UTC()
+	}
+
 	return &Document{
 		Metadata: DocumentMetadata{
 			Title:           title,
 			Author:          author,
 			Version:         version,
 			Description:     description,
-			Build:           time.Unix(currentTime.Unix(), 0).UTC(),
+			Build:           build,
 			RendererVersion: rendererVersion,
 		},
 		ScalarTypes:      make(ScalarTypes),
@@ -106,6 +135,7 @@ func NewDocument(
 		EntityTypes:      make(EntityTypes),
 		Relations:        make(EntityRelationTypes),
 		Types:            make(Types),
+		poisoned:         make(map[string]bool),
 	}, nil
 }
 
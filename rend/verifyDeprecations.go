@@ -0,0 +1,302 @@
+package rend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// DeprecatedItem represents a deprecated type, field,
+// enumeration value or relation
+type DeprecatedItem struct {
+	// Kind describes the kind of the deprecated item such as "field"
+	Kind string
+
+	// Name is the qualified name of the item such as "Actor.social"
+	Name string
+
+	// Anchor is the name of the document anchor of the item
+	Anchor string
+
+	// Path is the path of the declaration of the item
+	// in the source document
+	Path []string
+
+	Deprecation *Deprecation
+}
+
+// deprecationOf returns the deprecation notice of the given type
+// or nil if the type isn't deprecated
+func deprecationOf(t AbstractType) *Deprecation {
+	switch t := t.(type) {
+	case *ScalarType:
+		return t.Deprecated
+	case *EnumerationType:
+		return t.Deprecated
+	case *CompositeType:
+		return t.Deprecated
+	case *EntityType:
+		return t.Deprecated
+	case *EntityRelationType:
+		return t.Deprecated
+	}
+	return nil
+}
+
+// IsDeprecated returns true if the given type is deprecated,
+// otherwise returns false
+func (d *Document) IsDeprecated(t AbstractType) bool {
+	return deprecationOf(t) != nil
+}
+
+// sortedFieldNames returns the names of the given metadata fields
+// in alphabetical order
+func sortedFieldNames(metadata Metadata) []string {
+	names := make([]string, 0, len(metadata))
+	for name := range metadata {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// DeprecatedItems returns all deprecated items of the document
+// sorted by their qualified names
+func (d *Document) DeprecatedItems() []DeprecatedItem {
+	items := make([]DeprecatedItem, 0)
+	add := func(
+		kind,
+		name,
+		anchor string,
+		path []string,
+		deprecation *Deprecation,
+	) {
+		if deprecation == nil {
+			return
+		}
+		items = append(items, DeprecatedItem{
+			Kind:        kind,
+			Name:        name,
+			Anchor:      anchor,
+			Path:        path,
+			Deprecation: deprecation,
+		})
+	}
+	addFields := func(prefix, anchor string, origin ComplexType) {
+		metadata := origin.MetaInformation()
+		for _, fieldName := range sortedFieldNames(metadata) {
+			add(
+				"field",
+				prefix+"."+fieldName,
+				anchor,
+				fieldPath(origin, fieldName),
+				metadata[fieldName].Deprecated,
+			)
+		}
+	}
+
+	for typeName, t := range d.Types {
+		if _, isRelation := t.(*EntityRelationType); isRelation {
+			// Relations are listed by their declaring entity types
+			continue
+		}
+		add(
+			t.TypeCategory().String()+" type",
+			typeName,
+			typeName,
+			declarationPath(t),
+			deprecationOf(t),
+		)
+
+		switch t := t.(type) {
+		case *EnumerationType:
+			for item, value := range t.Values {
+				add(
+					"enumeration value",
+					typeName+"."+item,
+					typeName,
+					appendPath(declarationPath(t), "values", item),
+					value.Deprecated,
+				)
+			}
+		case *CompositeType:
+			addFields(typeName, typeName, t)
+		case *EntityType:
+			addFields(typeName, typeName, t)
+			for relationName, relation := range t.Relations {
+				qualifiedName := typeName + "." + relationName
+				add(
+					"relation",
+					qualifiedName,
+					typeName,
+					declarationPath(relation),
+					relation.Deprecated,
+				)
+				addFields(qualifiedName, typeName, relation)
+			}
+		}
+	}
+
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].Name == items[j].Name {
+			return items[i].Kind < items[j].Kind
+		}
+		return items[i].Name < items[j].Name
+	})
+	return items
+}
+
+// resolveReference returns the type declaring the given type ("Type")
+// or type member ("Type.member") reference from within the given namespace
+// and true if the reference is resolved, otherwise returns false
+func (d *Document) resolveReference(
+	namespace string,
+	reference string,
+) (AbstractType, bool) {
+	if t, isDefined := d.LookupType(namespace, reference); isDefined {
+		return t, true
+	}
+
+	separator := strings.LastIndex(reference, ".")
+	if separator < 0 {
+		return nil, false
+	}
+	t, isDefined := d.LookupType(namespace, reference[:separator])
+	if !isDefined {
+		return nil, false
+	}
+	member := reference[separator+1:]
+
+	switch t := t.(type) {
+	case *EnumerationType:
+		_, isItem := t.Values[member]
+		return t, isItem
+	case *EntityType:
+		if _, isRelation := t.Relations[member]; isRelation {
+			return t, true
+		}
+		_, isField := t.Metadata[member]
+		return t, isField
+	case ComplexType:
+		_, isField := t.MetaInformation()[member]
+		return t, isField
+	}
+	return nil, false
+}
+
+// isPoisonedReference returns true if the given type ("Type")
+// or type member ("Type.member") reference from within the given namespace
+// references a poisoned type, otherwise returns false
+func (d *Document) isPoisonedReference(
+	namespace string,
+	reference string,
+) bool {
+	if d.isPoisoned(d.qualifyTypeName(nil, namespace, reference)) {
+		return true
+	}
+	separator := strings.LastIndex(reference, ".")
+	return separator >= 0 && d.isPoisoned(
+		d.qualifyTypeName(nil, namespace, reference[:separator]),
+	)
+}
+
+// verifyDeprecations returns errors if any deprecation notice references
+// an undefined replacement and warnings for every non-deprecated field
+// or relation referencing a deprecated type
+func (d *Document) verifyDeprecations() (errors ModelErrors) {
+	// Verify and link the replacements
+	for _, item := range d.DeprecatedItems() {
+		replacement := item.Deprecation.Replacement
+		if replacement == "" {
+			continue
+		}
+		// Replacements are resolved relative to the namespace
+		// of the type declaring the deprecated item
+		namespace, _ := document.SplitTypeName(item.Path[1])
+		if d.isPoisonedReference(namespace, replacement) {
+			// Errors of the poisoned type are already reported
+			continue
+		}
+		replacementType, isDefined := d.resolveReference(namespace, replacement)
+		if !isDefined {
+			errors.AddErrInvalidReplacement(replacement, Location{
+				Description: fmt.Sprintf(
+					"deprecation notice of %s '%s'",
+					item.Kind,
+					item.Name,
+				),
+				Path: appendPath(item.Path, "deprecated", "replacement"),
+			})
+			continue
+		}
+		item.Deprecation.ReplacementType = replacementType
+	}
+
+	// Warn about non-deprecated references to deprecated types
+	verifyFields := func(origin ComplexType) {
+		metadata := origin.MetaInformation()
+		for _, fieldName := range sortedFieldNames(metadata) {
+			field := metadata[fieldName]
+			if field.Deprecated != nil || field.Type == nil ||
+				!d.IsDeprecated(field.Type) {
+				continue
+			}
+			errors.AddWarnDeprecatedTypeUsage(
+				field.TypeName,
+				fieldLocation(origin, fieldName),
+			)
+		}
+	}
+
+	typeNames := make([]string, 0, len(d.Types))
+	for typeName := range d.Types {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		switch t := d.Types[typeName].(type) {
+		case *CompositeType:
+			if t.Deprecated == nil {
+				verifyFields(t)
+			}
+		case *EntityType:
+			if t.Deprecated != nil {
+				continue
+			}
+			verifyFields(t)
+
+			relationNames := make([]string, 0, len(t.Relations))
+			for relationName := range t.Relations {
+				relationNames = append(relationNames, relationName)
+			}
+			sort.Strings(relationNames)
+			for _, relationName := range relationNames {
+				relation := t.Relations[relationName]
+				if relation.Deprecated != nil {
+					continue
+				}
+				verifyFields(relation)
+				if relation.RelatedType != nil &&
+					d.IsDeprecated(relation.RelatedType) {
+					errors.AddWarnDeprecatedTypeUsage(
+						relation.RelatedTypeName,
+						relationLocation(
+							typeName,
+							relationName,
+							fmt.Sprintf(
+								"relation '%s' of entity type '%s'",
+								relationName,
+								typeName,
+							),
+						),
+					)
+				}
+			}
+		}
+	}
+
+	return errors
+}
@@ -0,0 +1,92 @@
+package rend
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// enumerationValueLocation returns the location of the declaration
+// of the given item of the given enumeration type
+func enumerationValueLocation(t *EnumerationType, item string) Location {
+	return Location{
+		Description: fmt.Sprintf(
+			"item '%s' of enumeration type '%s'",
+			item,
+			t.TypeName,
+		),
+		Path: appendPath(declarationPath(t), "values", item),
+	}
+}
+
+// verifyEnumerationValues returns errors if the values of the items
+// of the given enumeration type are of different kinds, if a value
+// is declared by more than one item or if an alias is either
+// declared by more than one item or the name of another item
+func (d *Document) verifyEnumerationValues(
+	t *EnumerationType,
+) (errors ModelErrors) {
+	kind := document.NoValue
+	itemsByValue := make(map[string]string, len(t.Values))
+	itemsByName := make(map[string]string, len(t.Values))
+	for item := range t.Values {
+		itemsByName[item] = item
+	}
+
+	// Verify the items in the declaration order to report the later
+	// declarations of values and aliases
+	values := make([]EnumerationValue, 0, len(t.Values))
+	for _, value := range t.Values {
+		values = append(values, value)
+	}
+	sort.Slice(values, func(i, j int) bool {
+		return DeclarationOrder.less(
+			values[i].Index, values[i].Name,
+			values[j].Index, values[j].Name,
+		)
+	})
+	for _, value := range values {
+		location := enumerationValueLocation(t, value.Name)
+
+		if value.Kind != document.NoValue {
+			if kind == document.NoValue {
+				kind = value.Kind
+			}
+			if value.Kind != kind {
+				errors.AddErrInconsistentEnumerationValue(
+					t,
+					value.Name,
+					value.Kind,
+					kind,
+					location,
+				)
+			} else if other, isDeclared := itemsByValue[value.Value]; isDeclared {
+				errors.AddErrDuplicateEnumerationValue(
+					t,
+					value.Name,
+					fmt.Sprintf("value '%s'", value.Value),
+					other,
+					location,
+				)
+			} else {
+				itemsByValue[value.Value] = value.Name
+			}
+		}
+
+		for _, alias := range value.Aliases {
+			if other, isDeclared := itemsByName[alias]; isDeclared {
+				errors.AddErrDuplicateEnumerationValue(
+					t,
+					value.Name,
+					fmt.Sprintf("alias '%s'", alias),
+					other,
+					location,
+				)
+				continue
+			}
+			itemsByName[alias] = value.Name
+		}
+	}
+	return errors
+}
@@ -4,8 +4,9 @@ import "fmt"
 
 // verifyMetaFieldType returns errors if the given type (ref)
 // can't be used as a metadata field type, otherwise returns nil
-func verifyMetaFieldType(
-	originTypeName,
+func (d *Document) verifyMetaFieldType(
+	forwardDeclared Types,
+	origin ComplexType,
 	fieldName,
 	fieldTypeName string,
 	ref AbstractType,
@@ -15,14 +16,15 @@ func verifyMetaFieldType(
 		// Can't use entity types as field types!
 		errors.AddErrEntityNesting(
 			fieldTypeName,
-			originTypeName,
+			origin,
 			fieldName,
+			d.suggestType(forwardDeclared, fieldTypeName, IsFieldType),
 		)
 	case *EntityRelationType:
 		// Can't use relation types as field types!
 		errors.AddErrRelationTypeAsField(
 			fieldTypeName,
-			originTypeName,
+			origin,
 			fieldName,
 		)
 	case nil:
@@ -31,7 +33,7 @@ func verifyMetaFieldType(
 				"during metadata field '%s' type verification "+
 				" of type %s, expected a reference to type '%s'",
 			fieldName,
-			originTypeName,
+			origin.Name(),
 			fieldTypeName,
 		))
 	}
@@ -55,6 +57,18 @@ func (d *Document) verifyMetadataIntegrity(
 
 	metadata := origin.MetaInformation()
 
+	// Qualify the referenced type names
+	// relative to the namespace of the origin type
+	namespace := Namespace(origin)
+	for fieldName, field := range metadata {
+		field.TypeName = d.qualifyTypeName(
+			forwardDeclared,
+			namespace,
+			field.TypeName,
+		)
+		metadata[fieldName] = field
+	}
+
 	for fieldName, field := range metadata {
 		// Check whether the type of the field is declared
 		// in either the registry or the list of new yet unregistered types
@@ -67,8 +81,9 @@ func (d *Document) verifyMetadataIntegrity(
 		}
 
 		if isDeclared {
-			if errs := verifyMetaFieldType(
-				origin.Name(),
+			if errs := d.verifyMetaFieldType(
+				forwardDeclared,
+				origin,
 				fieldName,
 				field.TypeName,
 				registryRef,
@@ -77,8 +92,9 @@ func (d *Document) verifyMetadataIntegrity(
 				continue
 			}
 		} else if isForwardDeclared {
-			if errs := verifyMetaFieldType(
-				origin.Name(),
+			if errs := d.verifyMetaFieldType(
+				forwardDeclared,
+				origin,
 				fieldName,
 				field.TypeName,
 				forwardDeclaredRef,
@@ -86,12 +102,28 @@ func (d *Document) verifyMetadataIntegrity(
 				errors.Add(errs...)
 				continue
 			}
+		} else if d.declaredEntityTypes[field.TypeName] {
+			// Entity types referenced by composite types
+			// aren't registered yet
+			errors.AddErrEntityNesting(
+				field.TypeName,
+				origin,
+				fieldName,
+				d.suggestType(forwardDeclared, field.TypeName, IsFieldType),
+			)
+			continue
+		} else if d.isPoisoned(field.TypeName) {
+			// Errors of the poisoned type are already reported,
+			// the origin type is poisoned by its registration
+			continue
 		} else {
 			// Referenced type is undefined
 			errors.AddErrUndefinedTypeInMetaField(
 				origin,         // origin type
 				fieldName,      // field name
 				field.TypeName, // undefined type
+				d.suggestType(forwardDeclared, field.TypeName, IsFieldType),
+				d.suggestEntityType(forwardDeclared, field.TypeName),
 			)
 			continue
 		}
@@ -112,6 +144,9 @@ func (d *Document) verifyMetadataIntegrity(
 			Nullable:    field.Nullable,
 			IsList:      field.IsList,
 			TypeName:    field.TypeName,
+			Examples:    field.Examples,
+			Deprecated:  field.Deprecated,
+			Index:       field.Index,
 		}
 	}
 
@@ -0,0 +1,199 @@
+package rend
+
+import "sort"
+
+// cycleField represents a field of a type on a containment cycle
+type cycleField struct {
+	typeName  string
+	fieldName string
+}
+
+// containment represents a non-nullable non-list field
+// of a composite type requiring a value of another composite type
+type containment struct {
+	fieldName string
+	target    string
+}
+
+// recursionVerifier represents the state of a search for composite types
+// containing themselves through non-nullable non-list fields
+type recursionVerifier struct {
+	types      map[string]*CompositeType
+	containers map[string][]containment
+
+	// Tarjan's strongly connected components algorithm state
+	index    int
+	indexes  map[string]int
+	lowLinks map[string]int
+	stack    []string
+	onStack  map[string]bool
+	cycles   [][]string
+}
+
+// newRecursionVerifier creates a new recursion verifier
+// for the given composite types
+func newRecursionVerifier(types []ComplexType) *recursionVerifier {
+	v := &recursionVerifier{
+		types:      make(map[string]*CompositeType, len(types)),
+		containers: make(map[string][]containment, len(types)),
+		indexes:    make(map[string]int, len(types)),
+		lowLinks:   make(map[string]int, len(types)),
+		onStack:    make(map[string]bool, len(types)),
+	}
+	for _, t := range types {
+		if composite, isComposite := t.(*CompositeType); isComposite {
+			v.types[composite.TypeName] = composite
+		}
+	}
+	for typeName, composite := range v.types {
+		for _, fieldName := range sortedFieldNames(composite.Metadata) {
+			field := composite.Metadata[fieldName]
+			if field.Nullable || field.IsList {
+				// Nullable fields and lists can be empty
+				continue
+			}
+			if _, isCandidate := v.types[field.TypeName]; !isCandidate {
+				continue
+			}
+			v.containers[typeName] = append(v.containers[typeName], containment{
+				fieldName: fieldName,
+				target:    field.TypeName,
+			})
+		}
+	}
+	return v
+}
+
+// connect visits the given type
+// collecting the strongly connected components containing a cycle
+func (v *recursionVerifier) connect(typeName string) {
+	v.indexes[typeName] = v.index
+	v.lowLinks[typeName] = v.index
+	v.index++
+	v.stack = append(v.stack, typeName)
+	v.onStack[typeName] = true
+
+	isSelfContained := false
+	for _, c := range v.containers[typeName] {
+		if c.target == typeName {
+			isSelfContained = true
+		}
+		if _, isVisited := v.indexes[c.target]; !isVisited {
+			v.connect(c.target)
+			if v.lowLinks[c.target] < v.lowLinks[typeName] {
+				v.lowLinks[typeName] = v.lowLinks[c.target]
+			}
+		} else if v.onStack[c.target] &&
+			v.indexes[c.target] < v.lowLinks[typeName] {
+			v.lowLinks[typeName] = v.indexes[c.target]
+		}
+	}
+
+	if v.lowLinks[typeName] != v.indexes[typeName] {
+		return
+	}
+
+	// Pop the strongly connected component of the type
+	component := []string{}
+	for {
+		last := v.stack[len(v.stack)-1]
+		v.stack = v.stack[:len(v.stack)-1]
+		v.onStack[last] = false
+		component = append(component, last)
+		if last == typeName {
+			break
+		}
+	}
+	if len(component) > 1 || isSelfContained {
+		sort.Strings(component)
+		v.cycles = append(v.cycles, component)
+	}
+}
+
+// shortestCycle returns the fields of the shortest cycle
+// leading from the given type back to itself
+// through the types of the given component
+func (v *recursionVerifier) shortestCycle(
+	typeName string,
+	component []string,
+) []cycleField {
+	isMember := make(map[string]bool, len(component))
+	for _, member := range component {
+		isMember[member] = true
+	}
+
+	// Breadth-first search remembering the field each type is reached by
+	reachedBy := make(map[string]cycleField, len(component))
+	queue := []string{typeName}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, c := range v.containers[current] {
+			if !isMember[c.target] {
+				continue
+			}
+			if _, isReached := reachedBy[c.target]; isReached {
+				continue
+			}
+			reachedBy[c.target] = cycleField{
+				typeName:  current,
+				fieldName: c.fieldName,
+			}
+			if c.target == typeName {
+				queue = nil
+				break
+			}
+			queue = append(queue, c.target)
+		}
+	}
+
+	// Walk back from the type to itself
+	cycle := []cycleField{}
+	for current := typeName; ; {
+		field := reachedBy[current]
+		cycle = append([]cycleField{field}, cycle...)
+		current = field.typeName
+		if current == typeName {
+			return cycle
+		}
+	}
+}
+
+// verifyRecursion returns errors for every group of the given
+// composite types containing each other through non-nullable non-list
+// fields since values of such types would be infinitely sized.
+// The types of such groups are poisoned.
+// Recursion through nullable fields and lists is legitimate
+func (d *Document) verifyRecursion(types []ComplexType) (errors ModelErrors) {
+	v := newRecursionVerifier(types)
+
+	typeNames := make([]string, 0, len(v.types))
+	for typeName := range v.types {
+		typeNames = append(typeNames, typeName)
+	}
+	sort.Strings(typeNames)
+
+	for _, typeName := range typeNames {
+		if _, isVisited := v.indexes[typeName]; !isVisited {
+			v.connect(typeName)
+		}
+	}
+
+	sort.Slice(v.cycles, func(i, j int) bool {
+		return v.cycles[i][0] < v.cycles[j][0]
+	})
+	for _, component := range v.cycles {
+		// Report the cycle once at the type of the smallest name
+		origin := v.types[component[0]]
+		cycle := v.shortestCycle(origin.TypeName, component)
+		fields := make([]string, len(cycle))
+		for i, field := range cycle {
+			fields[i] = field.typeName + "." + field.fieldName
+		}
+		errors.AddErrInfiniteRecursion(origin, cycle[0].fieldName, fields)
+		for _, typeName := range component {
+			d.poison(typeName)
+		}
+	}
+	return errors
+}
@@ -0,0 +1,33 @@
+package rend
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestVerifyRecursion verifies that composite types may reference
+// each other and themselves through nullable fields and lists
+func TestVerifyRecursion(t *testing.T) {
+	_, errs := newModel(t, "composite types:\n"+
+		"  Tree:\n"+
+		"    meta:\n"+
+		"      root:\n"+
+		"        type: Node\n"+
+		"  Node:\n"+
+		"    meta:\n"+
+		"      parent:\n"+
+		"        type: Node\n"+
+		"        nullable: true\n"+
+		"      children:\n"+
+		"        type: List<Node>\n"+
+		"      tree:\n"+
+		"        type: Tree\n")
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 problem, got %#v", errs)
+	}
+	err := errs[0]
+	if err.Code != ErrInfiniteRecursion ||
+		!strings.Contains(err.Message, "Node.tree -> Tree.root -> Node") {
+		t.Fatalf("unexpected problem: %#v", err)
+	}
+}
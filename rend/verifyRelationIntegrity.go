@@ -17,14 +17,15 @@ func verifyRelationType(
 		// Can't use entity types as field types!
 		errors.AddErrEntityNesting(
 			relationTypeName,
-			originType.Name(),
+			originType,
 			relationName,
+			"",
 		)
 	case *EntityRelationType:
 		// Can't use relation types as field types!
 		errors.AddErrRelationTypeAsField(
 			relationTypeName,
-			originType.Name(),
+			originType,
 			relationName,
 		)
 	case nil:
@@ -53,14 +54,15 @@ func verifyRelatedType(
 		// Can't use entity types as field types!
 		errors.AddErrEntityNesting(
 			relationTypeName,
-			originType.Name(),
+			originType,
 			relationName,
+			"",
 		)
 	case *EntityRelationType:
 		// Can't use relation types as field types!
 		errors.AddErrRelationTypeAsField(
 			relationTypeName,
-			originType.Name(),
+			originType,
 			relationName,
 		)
 	case nil:
@@ -90,14 +92,21 @@ func (d *Document) verifyRelationIntegrity(
 		panic(fmt.Errorf("missing relation type object"))
 	}
 
-	// Verify type name
-	errors.Add(d.verifyTypeName(
-		relation.TypeName.RelationType,
+	declarationLocation := relationLocation(
+		originType.Name(),
+		relationName,
 		fmt.Sprintf(
 			"relation '%s' of entity type '%s'",
 			relationName,
 			originType.Name(),
 		),
+	)
+
+	// Verify type name
+	errors.Add(d.verifyTypeName(
+		Relation,
+		relation.TypeName.RelationType,
+		declarationLocation,
 	)...)
 	if errors.HasErrors() {
 		// Don't evaluate further in case of illegal name
@@ -110,11 +119,7 @@ func (d *Document) verifyRelationIntegrity(
 	errors.Add(d.verifyType(
 		forwardDeclared,
 		relationTypeName,
-		fmt.Sprintf(
-			"relation '%s' of entity type '%s'",
-			relationName,
-			originType.Name(),
-		),
+		declarationLocation,
 	)...)
 	if errors.HasErrors() {
 		// Don't continue in case of illegal name
@@ -124,17 +129,44 @@ func (d *Document) verifyRelationIntegrity(
 	// Check metadata fields
 	errors.Add(d.verifyMetadataIntegrity(forwardDeclared, relation)...)
 
+	// The related type is the only type that can be undefined
+	// or inappropriate since the declaring type is always an entity
+	relatedTypePath := appendPath(
+		relationPath(originType.Name(), relationName),
+		"related type",
+	)
+	sourceLocation := Location{
+		Description: fmt.Sprintf(
+			"source type of relation '%s' of entity type '%s'",
+			relationName,
+			originType.Name(),
+		),
+		Path: relatedTypePath,
+	}
+	targetLocation := Location{
+		Description: fmt.Sprintf(
+			"target type of relation '%s' of entity type '%s'",
+			relationName,
+			originType.Name(),
+		),
+		Path: relatedTypePath,
+	}
+
 	// Verify source type
 	sourceTypeRegistry, isDeclared := d.Types[relation.SourceTypeName]
 	sourceTypeForwardDeclared, isForwardDeclared := forwardDeclared[relation.SourceTypeName]
-	if !isDeclared && !isForwardDeclared {
+	if !isDeclared && !isForwardDeclared && d.isPoisoned(relation.SourceTypeName) {
+		// Errors of the poisoned type are already reported
+		return errors
+	} else if !isDeclared && !isForwardDeclared {
 		errors.AddErrUndefinedType(
 			relation.SourceTypeName,
-			fmt.Sprintf(
-				"source type of relation '%s' of entity type '%s'",
-				relationName,
-				originType.Name(),
+			d.suggestType(
+				forwardDeclared,
+				relation.SourceTypeName,
+				isEntityType,
 			),
+			sourceLocation,
 		)
 		return errors
 	} else if isDeclared {
@@ -142,13 +174,14 @@ func (d *Document) verifyRelationIntegrity(
 		switch sourceTypeRegistry.(type) {
 		case *EntityType:
 		default:
-			errors.AddErrInappropriateType(
-				relation.SourceTypeName,
-				fmt.Sprintf(
-					"source type of relation '%s' of entity type '%s'",
-					relationName,
-					originType.Name(),
+			errors.AddErrInappropriateRelationTarget(
+				sourceTypeRegistry,
+				d.suggestType(
+					forwardDeclared,
+					relation.SourceTypeName,
+					isEntityType,
 				),
+				sourceLocation,
 			)
 			return
 		}
@@ -157,13 +190,14 @@ func (d *Document) verifyRelationIntegrity(
 		switch sourceTypeForwardDeclared.(type) {
 		case *EntityType:
 		default:
-			errors.AddErrInappropriateType(
-				relation.SourceTypeName,
-				fmt.Sprintf(
-					"source type of relation '%s' of entity type '%s'",
-					relationName,
-					originType.Name(),
+			errors.AddErrInappropriateRelationTarget(
+				sourceTypeForwardDeclared,
+				d.suggestType(
+					forwardDeclared,
+					relation.SourceTypeName,
+					isEntityType,
 				),
+				sourceLocation,
 			)
 			return
 		}
@@ -172,14 +206,18 @@ func (d *Document) verifyRelationIntegrity(
 	// Verify target type
 	targetTypeRegistry, isDeclared := d.Types[relation.TargetTypeName]
 	targetTypeForwardDeclared, isForwardDeclared := forwardDeclared[relation.TargetTypeName]
-	if !isDeclared && !isForwardDeclared {
+	if !isDeclared && !isForwardDeclared && d.isPoisoned(relation.TargetTypeName) {
+		// Errors of the poisoned type are already reported
+		return errors
+	} else if !isDeclared && !isForwardDeclared {
 		errors.AddErrUndefinedType(
 			relation.TargetTypeName,
-			fmt.Sprintf(
-				"target type of relation '%s' of entity type '%s'",
-				relationName,
-				originType.Name(),
+			d.suggestType(
+				forwardDeclared,
+				relation.TargetTypeName,
+				isEntityType,
 			),
+			targetLocation,
 		)
 		return errors
 	} else if isDeclared {
@@ -187,13 +225,14 @@ func (d *Document) verifyRelationIntegrity(
 		switch targetTypeRegistry.(type) {
 		case *EntityType:
 		default:
-			errors.AddErrInappropriateType(
-				relation.TargetTypeName,
-				fmt.Sprintf(
-					"target type of relation '%s' of entity type '%s'",
-					relationName,
-					originType.Name(),
+			errors.AddErrInappropriateRelationTarget(
+				targetTypeRegistry,
+				d.suggestType(
+					forwardDeclared,
+					relation.TargetTypeName,
+					isEntityType,
 				),
+				targetLocation,
 			)
 			return
 		}
@@ -202,13 +241,14 @@ func (d *Document) verifyRelationIntegrity(
 		switch targetTypeForwardDeclared.(type) {
 		case *EntityType:
 		default:
-			errors.AddErrInappropriateType(
-				relation.TargetTypeName,
-				fmt.Sprintf(
-					"target type of relation '%s' of entity type '%s'",
-					relationName,
-					originType.Name(),
+			errors.AddErrInappropriateRelationTarget(
+				targetTypeForwardDeclared,
+				d.suggestType(
+					forwardDeclared,
+					relation.TargetTypeName,
+					isEntityType,
 				),
+				targetLocation,
 			)
 			return
 		}
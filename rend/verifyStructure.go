@@ -0,0 +1,35 @@
+package rend
+
+import "github.com/romshark/TypeBook/document"
+
+// verifyStructure reports the structural problems
+// of the given document template
+func verifyStructure(doc *document.Document) (errors ModelErrors) {
+	for _, problem := range doc.Problems {
+		location := Location{
+			Description: FormatPath(problem.Path),
+			Path:        problem.Path,
+			Occurrence:  problem.Occurrence,
+		}
+		switch problem.Kind {
+		case document.UnknownKey:
+			errors.AddErrUnknownKey(
+				problem.Key(),
+				problem.Suggestion,
+				location,
+			)
+		case document.DuplicateKey:
+			errors.AddErrDuplicateKey(problem.Key(), location)
+		case document.InvalidValueKind:
+			errors.AddErrInvalidValueKind(
+				problem.Key(),
+				problem.Expected,
+				problem.Actual,
+				location,
+			)
+		case document.MissingKey:
+			errors.AddErrMissingKey(problem.Key(), location)
+		}
+	}
+	return errors
+}
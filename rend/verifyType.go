@@ -12,7 +12,7 @@ import (
 func (d *Document) verifyType(
 	forwardDeclared Types,
 	typeName string,
-	declarationLocation string,
+	declarationLocation Location,
 ) (errors ModelErrors) {
 	determineName := func(ref AbstractType) string {
 		switch ref.(type) {
@@ -1,19 +1,48 @@
 package rend
 
-import "regexp"
+import (
+	"strings"
 
-var typeNameRule = regexp.MustCompile("^[A-Z][a-zA-Z]+$")
+	"github.com/romshark/TypeBook/document"
+)
 
 // verifyTypeName returns an error if the given type name
-// violates type name rules, otherwise returns nil
+// violates the naming rules of the given category or, if it's qualified,
+// the naming rule of namespaces, otherwise returns nil.
+// Relation type names can't be qualified
 func (d *Document) verifyTypeName(
+	category TypeCategory,
 	typeName string,
-	declarationLocation string,
+	declarationLocation Location,
 ) (errors ModelErrors) {
+	namespace, localName := document.SplitTypeName(typeName)
+	if category == Relation {
+		namespace, localName = "", typeName
+	}
+
+	// Verify namespaces
+	if namespace != "" {
+		rule := d.naming.namespaceRule()
+		for _, name := range strings.Split(namespace, ".") {
+			if !rule.MatchString(name) {
+				errors.AddErrIllegalTypeName(
+					typeName,
+					name,
+					rule.String(),
+					declarationLocation,
+				)
+				return errors
+			}
+		}
+	}
+
 	// Verify type name
-	if !typeNameRule.MatchString(typeName) {
+	rule := d.naming.typeNameRule(category)
+	if !rule.MatchString(localName) {
 		errors.AddErrIllegalTypeName(
 			typeName,
+			localName,
+			rule.String(),
 			declarationLocation,
 		)
 	}
@@ -0,0 +1,361 @@
+package rend
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+	"unicode/utf8"
+
+	"github.com/romshark/TypeBook/document"
+)
+
+// valueKind returns the name of the kind of the given decoded value
+func valueKind(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case int, int64, uint, uint64, float64:
+		return "number"
+	case string:
+		return "string"
+	case time.Time:
+		return "time"
+	case []interface{}:
+		return "list"
+	case map[string]interface{}, document.Object:
+		return "object"
+	}
+	return fmt.Sprintf("%T", value)
+}
+
+// numericValue returns the given decoded value as a float
+// and true if it's numeric, otherwise returns false
+func numericValue(value interface{}) (float64, bool) {
+	switch v := value.(type) {
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// objectValue returns the given decoded value as an object
+// and true if it's an object, otherwise returns false
+func objectValue(value interface{}) (map[string]interface{}, bool) {
+	// Mappings are decoded with raw string keys,
+	// mappings of complex keys aren't objects
+	switch v := value.(type) {
+	case map[string]interface{}:
+		return v, true
+	case document.Object:
+		return v, true
+	}
+	return nil, false
+}
+
+// VerifyFieldValue returns errors if the given decoded value violates
+// the nullability, list-ness or the type of the given metadata field,
+// otherwise returns nil. path identifies the value in error locations
+func (d *Document) VerifyFieldValue(
+	field TypedField,
+	value interface{},
+	path []string,
+) (errors ModelErrors) {
+	if value == nil {
+		if !field.Nullable {
+			errors.AddErrMissingValue(
+				fmt.Sprintf(
+					"missing value of non-nullable field '%s'",
+					field.Name,
+				),
+				path,
+			)
+		}
+		return errors
+	}
+
+	if !field.IsList {
+		return d.VerifyValue(field.Type, value, path)
+	}
+
+	list, isList := value.([]interface{})
+	if !isList {
+		errors.AddErrInvalidValue(
+			fmt.Sprintf(
+				"expected a list of '%s', got %s",
+				field.TypeName,
+				valueKind(value),
+			),
+			path,
+		)
+		return errors
+	}
+	for index, item := range list {
+		// List items are never nullable
+		errors.Add(d.VerifyValue(
+			field.Type,
+			item,
+			appendPath(path, strconv.Itoa(index)),
+		)...)
+	}
+	return errors
+}
+
+// VerifyValue returns errors if the given decoded value
+// isn't a valid value of the given type, otherwise returns nil.
+// path identifies the value in error locations
+func (d *Document) VerifyValue(
+	typeRef AbstractType,
+	value interface{},
+	path []string,
+) (errors ModelErrors) {
+	if value == nil {
+		errors.AddErrMissingValue(
+			fmt.Sprintf("missing value of type '%s'", typeRef.Name()),
+			path,
+		)
+		return errors
+	}
+
+	switch t := typeRef.(type) {
+	case *ScalarType:
+		return verifyScalarValue(t, value, path)
+	case *EnumerationType:
+		return verifyEnumerationValue(t, value, path)
+	case ComplexType:
+		return d.verifyComplexValue(t, value, path)
+	}
+	panic(fmt.Errorf(
+		"unexpected type '%T' during value verification",
+		typeRef,
+	))
+}
+
+// verifyScalarValue verifies the kind and the constraints
+// of a scalar type value
+func verifyScalarValue(
+	t *ScalarType,
+	value interface{},
+	path []string,
+) (errors ModelErrors) {
+	kind := valueKind(value)
+	mismatch := func() ModelErrors {
+		errors.AddErrInvalidValue(
+			fmt.Sprintf(
+				"expected %s value of scalar type '%s', got %s",
+				t.Kind,
+				t.TypeName,
+				kind,
+			),
+			path,
+		)
+		return errors
+	}
+
+	switch t.Kind {
+	case document.AnyKind:
+		if kind == "list" || kind == "object" {
+			return mismatch()
+		}
+	case document.StringKind:
+		if kind != "string" {
+			return mismatch()
+		}
+	case document.NumberKind:
+		if kind != "number" {
+			return mismatch()
+		}
+	case document.IntegerKind:
+		num, isNumeric := numericValue(value)
+		if !isNumeric || num != math.Trunc(num) {
+			return mismatch()
+		}
+	case document.BooleanKind:
+		if kind != "boolean" {
+			return mismatch()
+		}
+	case document.TimeKind:
+		if kind == "time" {
+			break
+		}
+		str, isString := value.(string)
+		if !isString {
+			return mismatch()
+		}
+		if _, err := time.Parse(time.RFC3339, str); err != nil {
+			errors.AddErrInvalidValue(
+				fmt.Sprintf(
+					"'%s' is not an RFC3339 datetime of scalar type '%s'",
+					str,
+					t.TypeName,
+				),
+				path,
+			)
+			return errors
+		}
+	}
+
+	// Verify constraints
+	constraints := t.Constraints
+	if str, isString := value.(string); isString {
+		length := uint32(utf8.RuneCountInString(str))
+		if constraints.Pattern != nil && !constraints.Pattern.MatchString(str) {
+			errors.AddErrInvalidValue(
+				fmt.Sprintf(
+					"'%s' doesn't match the pattern '%s' of scalar type '%s'",
+					str,
+					constraints.Pattern,
+					t.TypeName,
+				),
+				path,
+			)
+		}
+		if constraints.MinLength != nil && length < *constraints.MinLength {
+			errors.AddErrInvalidValue(
+				fmt.Sprintf(
+					"'%s' is shorter than %d characters "+
+						"required by scalar type '%s'",
+					str,
+					*constraints.MinLength,
+					t.TypeName,
+				),
+				path,
+			)
+		}
+		if constraints.MaxLength != nil && length > *constraints.MaxLength {
+			errors.AddErrInvalidValue(
+				fmt.Sprintf(
+					"'%s' is longer than %d characters "+
+						"allowed by scalar type '%s'",
+					str,
+					*constraints.MaxLength,
+					t.TypeName,
+				),
+				path,
+			)
+		}
+	}
+	if num, isNumeric := numericValue(value); isNumeric {
+		if constraints.Minimum != nil && num < *constraints.Minimum {
+			errors.AddErrInvalidValue(
+				fmt.Sprintf(
+					"%v is less than the minimum %v of scalar type '%s'",
+					value,
+					*constraints.Minimum,
+					t.TypeName,
+				),
+				path,
+			)
+		}
+		if constraints.Maximum != nil && num > *constraints.Maximum {
+			errors.AddErrInvalidValue(
+				fmt.Sprintf(
+					"%v is greater than the maximum %v of scalar type '%s'",
+					value,
+					*constraints.Maximum,
+					t.TypeName,
+				),
+				path,
+			)
+		}
+	}
+	return errors
+}
+
+// verifyEnumerationValue verifies whether the given value
+// is the name or an alias of an item of the given enumeration type
+func verifyEnumerationValue(
+	t *EnumerationType,
+	value interface{},
+	path []string,
+) (errors ModelErrors) {
+	item, isString := value.(string)
+	if !isString {
+		errors.AddErrInvalidValue(
+			fmt.Sprintf(
+				"expected an item of enumeration type '%s', got %s",
+				t.TypeName,
+				valueKind(value),
+			),
+			path,
+		)
+		return errors
+	}
+	if _, isItem := t.Item(item); !isItem {
+		errors.AddErrInvalidValue(
+			fmt.Sprintf(
+				"'%s' is not an item of enumeration type '%s'",
+				item,
+				t.TypeName,
+			),
+			path,
+		)
+	}
+	return errors
+}
+
+// verifyComplexValue verifies the fields of a composite-,
+// entity- or relation type value
+func (d *Document) verifyComplexValue(
+	t ComplexType,
+	value interface{},
+	path []string,
+) (errors ModelErrors) {
+	obj, isObject := objectValue(value)
+	if !isObject {
+		errors.AddErrInvalidValue(
+			fmt.Sprintf(
+				"expected an object of type '%s', got %s",
+				t.Name(),
+				valueKind(value),
+			),
+			path,
+		)
+		return errors
+	}
+
+	metadata := t.MetaInformation()
+
+	// Verify the fields in a deterministic order
+	fieldNames := make([]string, 0, len(metadata))
+	for fieldName := range metadata {
+		fieldNames = append(fieldNames, fieldName)
+	}
+	sort.Strings(fieldNames)
+	for _, fieldName := range fieldNames {
+		errors.Add(d.VerifyFieldValue(
+			metadata[fieldName],
+			obj[fieldName],
+			appendPath(path, fieldName),
+		)...)
+	}
+
+	// Reject undefined fields
+	undefinedFields := make([]string, 0)
+	for fieldName := range obj {
+		if _, isDefined := metadata[fieldName]; !isDefined {
+			undefinedFields = append(undefinedFields, fieldName)
+		}
+	}
+	sort.Strings(undefinedFields)
+	for _, fieldName := range undefinedFields {
+		errors.AddErrUndefinedField(
+			fieldName,
+			t,
+			document.Suggest(fieldName, fieldNames),
+			appendPath(path, fieldName),
+		)
+	}
+	return errors
+}
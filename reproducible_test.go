@@ -0,0 +1,56 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// renderExample renders example.yml with the given build time
+func renderExample(t *testing.T, build time.Time) []byte {
+	renderer, _, err := rend.New()
+	if err != nil {
+		t.Fatalf("couldn't initialize renderer: %s", err)
+	}
+	doc, _, err := document.NewFromFile("./example.yml")
+	if err != nil {
+		t.Fatalf("couldn't read document: %s", err)
+	}
+	model, errs, _, err := rend.NewModel(doc, build)
+	if err != nil {
+		t.Fatalf("couldn't initialize document model: %s", err)
+	}
+	if errs.HasErrors() {
+		t.Fatalf("unexpected model errors: %v", errs)
+	}
+	var buf bytes.Buffer
+	if _, err := renderer.Render(model, &buf); err != nil {
+		t.Fatalf("couldn't render document: %s", err)
+	}
+	return buf.Bytes()
+}
+
+// TestReproducibleBuild verifies that rendering identical inputs
+// produces byte-identical output
+func TestReproducibleBuild(t *testing.T) {
+	build := time.Unix(1500000000, 0)
+
+	first := renderExample(t, build)
+	second := renderExample(t, build)
+	if !bytes.Equal(first, second) {
+		t.Fatalf("rendering example.yml twice produced different output")
+	}
+}
+
+// TestOmittedBuildTime verifies that a zero build time
+// is omitted from the rendered output
+func TestOmittedBuildTime(t *testing.T) {
+	output := string(renderExample(t, time.Time{}))
+	if strings.Contains(output, "Build:") {
+		t.Fatalf("expected the build time to be omitted")
+	}
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// scalarTypeMap represents a repeatable flag mapping
+// scalar type names to the types of a target schema language
+type scalarTypeMap map[string]string
+
+// String implements the flag.Value interface
+func (m scalarTypeMap) String() string {
+	mappings := make([]string, 0, len(m))
+	for name, columnType := range m {
+		mappings = append(mappings, name+"="+columnType)
+	}
+	return strings.Join(mappings, ",")
+}
+
+// Set implements the flag.Value interface
+func (m scalarTypeMap) Set(mapping string) error {
+	parts := strings.SplitN(mapping, "=", 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return fmt.Errorf("expected Name=type, got '%s'", mapping)
+	}
+	m[parts[0]] = parts[1]
+	return nil
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/romshark/TypeBook/compiler"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// site renders a schema document to a multi-page static site
+// written to a directory or a zip archive
+func site(args []string) {
+	flags := flag.NewFlagSet("site", flag.ExitOnError)
+	outputPath := flags.String(
+		"o",
+		"./site",
+		"Output directory or zip archive path if it ends with .zip",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	flags.StringVar(
+		buildTimestamp,
+		"build-time",
+		"",
+		"Build time in Unix seconds, \"now\" or \"none\"",
+	)
+	order := flags.String(
+		"order",
+		"declaration",
+		"Order of types, fields and values (declaration or alphabetical)",
+	)
+	flags.Parse(args)
+
+	if flags.NArg() < 1 {
+		log.Fatalf("Missing schema file path")
+	}
+
+	build, err := buildTime()
+	if err != nil {
+		log.Fatalf("Couldn't determine build time: %s", err)
+	}
+	options := compiler.Options{BuildTime: build}
+	if err := options.Order.FromString(*order); err != nil {
+		log.Fatalf("Invalid order: %s", err)
+	}
+
+	result, err := compiler.New(options).CompileFile(flags.Arg(0))
+	if err != nil {
+		log.Fatalf("Couldn't compile document: %s", err)
+	}
+	if writeDiagnostics(os.Stderr, *format, result.Diagnostics) {
+		os.Exit(1)
+	}
+
+	renderer, _, err := rend.NewSite()
+	if err != nil {
+		log.Fatalf("Couldn't initialize site renderer: %s", err)
+	}
+
+	if !strings.HasSuffix(*outputPath, ".zip") {
+		if _, err := renderer.Render(
+			result.Model,
+			rend.NewDirectoryWriter(*outputPath),
+		); err != nil {
+			log.Fatalf("Couldn't render site: %s", err)
+		}
+		return
+	}
+
+	file, err := os.Create(*outputPath)
+	if err != nil {
+		log.Fatalf("Couldn't create archive: %s", err)
+	}
+	defer file.Close()
+	archive := rend.NewZipWriter(file)
+	if _, err := renderer.Render(result.Model, archive); err != nil {
+		log.Fatalf("Couldn't render site: %s", err)
+	}
+	if err := archive.Close(); err != nil {
+		log.Fatalf("Couldn't write archive: %s", err)
+	}
+}
@@ -0,0 +1,10 @@
+// Package template provides the templates of the rendered document
+package template
+
+import "embed"
+
+// Files contains the template files of the single page document
+// and the templates and the stylesheet of the multi-page site
+//
+//go:embed *.html site
+var Files embed.FS
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/romshark/TypeBook/compiler"
+	"github.com/romshark/TypeBook/document"
+	"github.com/romshark/TypeBook/instance"
+	"github.com/romshark/TypeBook/rend"
+)
+
+// validate validates instance data against a schema document
+func validate(args []string) {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	schemaFilePath := flags.String(
+		"s",
+		"./example.yml",
+		"YAML schema file path",
+	)
+	dataFilePath := flags.String(
+		"d",
+		"",
+		"YAML or JSON instance data file path",
+	)
+	format := flags.String(
+		"format",
+		"text",
+		"Diagnostics output format (text, json or sarif)",
+	)
+	flags.Parse(args)
+
+	if *dataFilePath == "" {
+		log.Fatalf("Missing instance data file path (-d)")
+	}
+
+	document, _, err := document.NewFromFile(*schemaFilePath)
+	if err != nil {
+		log.Fatalf("Couldn't read document: %s", err)
+	}
+
+	// Create document model
+	documentModel, errs, _, err := rend.NewModel(document, time.Time{})
+	if err != nil {
+		log.Fatalf("Couldn't initialize document model: %s", err)
+	}
+	diagnostics := compiler.NewDiagnostics(*schemaFilePath, document.Source, errs)
+	if diagnostics.HasErrors() {
+		printDiagnostics(*format, diagnostics)
+		os.Exit(1)
+	}
+
+	data, err := instance.NewFromFile(*dataFilePath)
+	if err != nil {
+		log.Fatalf("Couldn't read instance data: %s", err)
+	}
+
+	// Validate the instance data against the document model
+	diagnostics = append(diagnostics, compiler.NewDiagnostics(
+		*dataFilePath,
+		data.Source,
+		instance.Validate(documentModel, data),
+	)...)
+	if printDiagnostics(*format, diagnostics) {
+		os.Exit(1)
+	}
+
+	if *format == "text" {
+		fmt.Printf("Valid: %s\n", *dataFilePath)
+	}
+}